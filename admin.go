@@ -0,0 +1,236 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// AdminPrefix stores the URL path prefix of the admin API of the mock servers,
+// enabled via WithAdmin or Handler.EnableAdmin.
+//
+// The admin API exposes the following JSON endpoints:
+//
+//	GET    /__httpmock/mocks       lists the registered mocks
+//	POST   /__httpmock/mocks       registers the given mock definition, or list of definitions
+//	DELETE /__httpmock/mocks       removes every registered mock
+//	DELETE /__httpmock/mocks/{id}  removes the given mock
+//	GET    /__httpmock/pending     lists the pending mocks
+//	GET    /__httpmock/unmatched   lists the requests which haven't matched any mock
+//	POST   /__httpmock/reset       removes every registered mock and unmatched request
+const AdminPrefix = "/__httpmock/"
+
+// AdminMock represents a registered mock in the admin API.
+type AdminMock struct {
+	ID      int    `json:"id"`
+	Method  string `json:"method,omitempty"`
+	URL     string `json:"url"`
+	Status  int    `json:"status,omitempty"`
+	Times   int    `json:"times"`
+	Persist bool   `json:"persist,omitempty"`
+	Calls   int    `json:"calls"`
+	Done    bool   `json:"done"`
+}
+
+// AdminRequest represents an unmatched request in the admin API.
+type AdminRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// serveAdmin serves the admin API requests.
+// Only the mocks and unmatched requests of the handler URL are exposed.
+func (h *Handler) serveAdmin(rw http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, AdminPrefix), "/")
+	h.pruneIDs()
+
+	switch {
+	case path == "mocks" && r.Method == http.MethodGet:
+		storeMutex.RLock()
+		mocks := append([]Mock{}, h.mocks.mocks...)
+		storeMutex.RUnlock()
+		writeJSON(rw, http.StatusOK, h.adminMocks(h.ownMocks(mocks)))
+
+	case path == "mocks" && r.Method == http.MethodPost:
+		h.createMocks(rw, r)
+
+	case path == "mocks" && r.Method == http.MethodDelete:
+		h.removeMocks()
+		rw.WriteHeader(http.StatusNoContent)
+
+	case strings.HasPrefix(path, "mocks/") && r.Method == http.MethodDelete:
+		id, err := strconv.Atoi(strings.TrimPrefix(path, "mocks/"))
+		if err != nil {
+			writeJSONError(rw, http.StatusBadRequest, "invalid mock id")
+			return
+		}
+		mock := h.mockByID(id)
+		if mock == nil {
+			writeJSONError(rw, http.StatusNotFound, "mock not found")
+			return
+		}
+		h.mocks.Remove(mock)
+		rw.WriteHeader(http.StatusNoContent)
+
+	case path == "pending" && r.Method == http.MethodGet:
+		writeJSON(rw, http.StatusOK, h.adminMocks(h.ownMocks(h.mocks.Pending())))
+
+	case path == "unmatched" && r.Method == http.MethodGet:
+		reqs := []AdminRequest{}
+		for _, req := range h.mocks.UnmatchedRequests() {
+			if servesRequest(h.URL, req) {
+				reqs = append(reqs, AdminRequest{Method: req.Method, URL: req.URL.String(), Header: req.Header})
+			}
+		}
+		writeJSON(rw, http.StatusOK, reqs)
+
+	case path == "reset" && r.Method == http.MethodPost:
+		h.removeMocks()
+		h.mocks.removeUnmatchedRequests(func(req *http.Request) bool {
+			return servesRequest(h.URL, req)
+		})
+		rw.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeJSONError(rw, http.StatusNotFound, "unknown admin endpoint")
+	}
+}
+
+// ownMocks returns the given mocks registered for the handler URL.
+func (h *Handler) ownMocks(mocks []Mock) []Mock {
+	res := []Mock{}
+	for _, mock := range mocks {
+		if servesMock(h.URL, mock) {
+			res = append(res, mock)
+		}
+	}
+	return res
+}
+
+// removeMocks removes the mocks registered for the handler URL.
+func (h *Handler) removeMocks() {
+	storeMutex.RLock()
+	mocks := append([]Mock{}, h.mocks.mocks...)
+	storeMutex.RUnlock()
+	for _, mock := range h.ownMocks(mocks) {
+		h.mocks.Remove(mock)
+	}
+}
+
+// createMocks registers the mock definitions of the given request body.
+func (h *Handler) createMocks(rw http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSONError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	defs, err := decodeJSONDefinitions(body)
+	if err != nil {
+		writeJSONError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate every definition before registering any mock, body files are relative to the working directory
+	for _, def := range defs {
+		if err := def.resolve("."); err != nil {
+			writeJSONError(rw, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	mocks := []Mock{}
+	for _, def := range defs {
+		mocks = append(mocks, def.Mock(h.URL).Mock)
+	}
+	writeJSON(rw, http.StatusCreated, h.adminMocks(mocks))
+}
+
+// adminMocks returns the admin API representation of the given mocks.
+func (h *Handler) adminMocks(mocks []Mock) []AdminMock {
+	res := []AdminMock{}
+	for _, mock := range mocks {
+		req := mock.Request()
+		res = append(res, AdminMock{
+			ID:      h.mockID(mock),
+			Method:  req.Method,
+			URL:     req.URLStruct.String(),
+			Status:  mock.Response().StatusCode,
+			Times:   req.Counter,
+			Persist: req.Persisted,
+			Calls:   len(Calls(mock)),
+			Done:    mock.Done(),
+		})
+	}
+	return res
+}
+
+// mockID returns the admin API identifier of the given mock, assigning a new one if needed.
+func (h *Handler) mockID(mock Mock) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.ids == nil {
+		h.ids = map[Mock]int{}
+	}
+	id, ok := h.ids[mock]
+	if !ok {
+		h.nextID++
+		id = h.nextID
+		h.ids[mock] = id
+	}
+	return id
+}
+
+// mockByID returns the registered mock with the given admin API identifier, if any.
+func (h *Handler) mockByID(id int) Mock {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for mock, mid := range h.ids {
+		if mid == id && h.mocks.Exists(mock) {
+			return mock
+		}
+	}
+	return nil
+}
+
+// pruneIDs forgets the admin API identifiers of the mocks which are no longer registered.
+func (h *Handler) pruneIDs() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for mock := range h.ids {
+		if !h.mocks.Exists(mock) {
+			delete(h.ids, mock)
+		}
+	}
+}
+
+// adminEnabled returns true if the admin API is enabled.
+func (h *Handler) adminEnabled() bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.admin
+}
+
+func writeJSON(rw http.ResponseWriter, status int, data interface{}) {
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		writeJSONError(rw, http.StatusInternalServerError, err.Error())
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	rw.Write(buf.Bytes())
+}
+
+func writeJSONError(rw http.ResponseWriter, status int, msg string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(map[string]string{"error": msg})
+}
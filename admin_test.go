@@ -0,0 +1,174 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func adminDo(t *testing.T, method, url, body string, out interface{}) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	if out != nil {
+		require.NoError(t, json.Unmarshal(data, out))
+	}
+	return res.StatusCode
+}
+
+func TestAdminMocks(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t, WithAdmin())
+	New(s.URL).Get("/foo").Reply(200)
+
+	var created []AdminMock
+	status := adminDo(t, "POST", s.URL+"/__httpmock/mocks", `[
+		{"request": {"method": "GET", "path": "/bar"}, "response": {"status": 202, "body": "bar"}},
+		{"request": {"method": "DELETE", "path": "/baz"}, "response": {"status": 204}}
+	]`, &created)
+	require.Equal(t, http.StatusCreated, status)
+	require.Len(t, created, 2)
+	require.Equal(t, "GET", created[0].Method)
+	require.Equal(t, 202, created[0].Status)
+
+	res, err := http.Get(s.URL + "/bar")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, 202, res.StatusCode)
+	require.Equal(t, "bar", string(body))
+
+	var mocks []AdminMock
+	require.Equal(t, http.StatusOK, adminDo(t, "GET", s.URL+"/__httpmock/mocks", "", &mocks))
+	require.Len(t, mocks, 2)
+	require.Equal(t, s.URL+"/foo", mocks[0].URL)
+	require.Equal(t, "DELETE", mocks[1].Method)
+
+	require.Equal(t, http.StatusNoContent, adminDo(t, "DELETE", s.URL+"/__httpmock/mocks/"+strconv.Itoa(mocks[1].ID), "", nil))
+	require.Equal(t, http.StatusNotFound, adminDo(t, "DELETE", s.URL+"/__httpmock/mocks/"+strconv.Itoa(mocks[1].ID), "", nil))
+
+	var pending []AdminMock
+	require.Equal(t, http.StatusOK, adminDo(t, "GET", s.URL+"/__httpmock/pending", "", &pending))
+	require.Len(t, pending, 1)
+	require.Equal(t, mocks[0].ID, pending[0].ID)
+
+	require.Equal(t, http.StatusNoContent, adminDo(t, "DELETE", s.URL+"/__httpmock/mocks", "", nil))
+	require.True(t, IsDone(t))
+}
+
+func TestAdminUnmatchedAndReset(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t, WithAdmin())
+	New(s.URL).Get("/foo").Reply(200)
+
+	_, err := http.Get(s.URL + "/unknown")
+	require.NoError(t, err)
+
+	var unmatched []AdminRequest
+	require.Equal(t, http.StatusOK, adminDo(t, "GET", s.URL+"/__httpmock/unmatched", "", &unmatched))
+	require.Len(t, unmatched, 1)
+	require.Equal(t, "GET", unmatched[0].Method)
+	require.Equal(t, s.URL+"/unknown", unmatched[0].URL)
+
+	require.Equal(t, http.StatusNoContent, adminDo(t, "POST", s.URL+"/__httpmock/reset", "", nil))
+	require.True(t, IsDone(t))
+	require.False(t, HasUnmatchedRequest(t))
+}
+
+func TestAdminErrors(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t, WithAdmin())
+
+	var res map[string]string
+	require.Equal(t, http.StatusBadRequest, adminDo(t, "POST", s.URL+"/__httpmock/mocks", `{`, &res))
+	require.NotEmpty(t, res["error"])
+	require.Equal(t, http.StatusBadRequest, adminDo(t, "DELETE", s.URL+"/__httpmock/mocks/foo", "", nil))
+	require.Equal(t, http.StatusNotFound, adminDo(t, "GET", s.URL+"/__httpmock/unknown", "", nil))
+
+	// Invalid definitions are rejected before any mock is registered
+	for _, body := range []string{
+		`[{"request": {"path": "/foo"}}, {"request": {"path": "/bar"}, "response": {"bodyFile": "/nonexistent"}}]`,
+		`{"request": {"path": "/foo"}, "exhausted": "bogus"}`,
+		`{"request": {"path": "/foo"}, "response": {"fault": "bogus"}}`,
+	} {
+		require.Equal(t, http.StatusBadRequest, adminDo(t, "POST", s.URL+"/__httpmock/mocks", body, &res))
+		require.NotEmpty(t, res["error"])
+	}
+	require.True(t, IsDone(t))
+	require.Empty(t, load(s.URL).Pending())
+	require.False(t, HasUnmatchedRequest(t))
+}
+
+func TestAdminDisabled(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).Get("/__httpmock/mocks").Reply(200).BodyString("mocked")
+
+	res, err := http.Get(s.URL + "/__httpmock/mocks")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "mocked", string(body))
+}
+
+func TestAdminScopedToServer(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t, WithAdmin())
+	other := Server(t)
+	New(s.URL).Get("/foo").Reply(200)
+	New(other.URL).Get("/bar").Reply(200)
+
+	_, err := http.Get(other.URL + "/unknown")
+	require.NoError(t, err)
+
+	var mocks []AdminMock
+	require.Equal(t, http.StatusOK, adminDo(t, "GET", s.URL+"/__httpmock/mocks", "", &mocks))
+	require.Len(t, mocks, 1)
+	require.Equal(t, s.URL+"/foo", mocks[0].URL)
+
+	var unmatched []AdminRequest
+	require.Equal(t, http.StatusOK, adminDo(t, "GET", s.URL+"/__httpmock/unmatched", "", &unmatched))
+	require.Len(t, unmatched, 0)
+
+	// Resetting the server keeps the other servers mocks and unmatched requests
+	require.Equal(t, http.StatusNoContent, adminDo(t, "POST", s.URL+"/__httpmock/reset", "", nil))
+	require.Len(t, load(s.URL).Pending(), 1)
+	require.True(t, HasUnmatchedRequest(t))
+	New(other.URL).Get("/unknown").Reply(200)
+	load(s.URL).Flush()
+	CleanUnmatchedRequest(t)
+}
+
+func TestAdminPrunesIDs(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t, WithAdmin())
+	handler := s.Config.Handler.(*Handler)
+
+	var created []AdminMock
+	adminDo(t, "POST", s.URL+"/__httpmock/mocks", `[{"request": {"path": "/foo"}}, {"request": {"path": "/bar"}}]`, &created)
+	require.Equal(t, http.StatusNoContent, adminDo(t, "DELETE", s.URL+"/__httpmock/mocks/"+strconv.Itoa(created[0].ID), "", nil))
+
+	// Identifiers of removed mocks are forgotten and never reused
+	adminDo(t, "POST", s.URL+"/__httpmock/mocks", `{"request": {"path": "/baz"}}`, &created)
+	require.Equal(t, 3, created[0].ID)
+	adminDo(t, "GET", s.URL+"/__httpmock/mocks", "", nil)
+	require.Len(t, handler.ids, 2)
+	require.Equal(t, http.StatusNoContent, adminDo(t, "DELETE", s.URL+"/__httpmock/mocks", "", nil))
+}
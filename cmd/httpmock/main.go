@@ -0,0 +1,95 @@
+// Command httpmock serves HTTP mocks defined in files, so the same fixtures
+// can be used by Go tests and by any other local service.
+//
+// Usage:
+//
+//	httpmock [-addr :8080] [-url http://localhost:8080] [-admin] mocks.json...
+//
+// Mocks which define neither times nor persist are persisted, so every fixture is served
+// for the server lifetime. Requests which don't match any mock are replied with a 501
+// status code and reported on the standard output. The -admin flag enables the admin API
+// under /__httpmock/, so the mocks can be driven remotely.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/empire/go-httpmock"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("httpmock", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	baseURL := flags.String("url", "", "public base URL of the server, defaults to http://<addr>")
+	admin := flags.Bool("admin", false, "enable the admin API under "+httpmock.AdminPrefix)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("httpmock: at least one mock definition file is required")
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	handler, err := newHandler(ln.Addr(), *baseURL, flags.Args(), stdout)
+	if err != nil {
+		return err
+	}
+	if *admin {
+		handler.EnableAdmin()
+	}
+
+	fmt.Fprintf(stdout, "httpmock: serving %d mock(s) on %s\n", len(handler.Pending()), handler.URL)
+	return http.Serve(ln, handler)
+}
+
+// newHandler creates the mocks handler loading the mock definition files.
+func newHandler(addr net.Addr, baseURL string, files []string, stdout io.Writer) (*httpmock.Handler, error) {
+	if baseURL == "" {
+		baseURL = "http://" + addr.String()
+	}
+
+	handler, err := httpmock.NewHandler(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		defs, err := httpmock.ReadDefinitions(file)
+		if err != nil {
+			return nil, fmt.Errorf("httpmock: cannot read %s: %w", file, err)
+		}
+		for _, def := range defs {
+			// Unlike in tests, mocks are served until the server stops by default
+			if def.Request.Times == 0 && !def.Request.Persist {
+				def.Request.Persist = true
+			}
+			def.Mock(handler.URL)
+		}
+	}
+
+	handler.Transport().ObserveResponse(func(req *http.Request, mock httpmock.Mock, res *http.Response, err error) {
+		if errors.Is(err, httpmock.ErrCannotMatch) {
+			fmt.Fprintf(stdout, "%v\n", err)
+		}
+	})
+
+	return handler, nil
+}
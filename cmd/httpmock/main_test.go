@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	stdout := &bytes.Buffer{}
+	handler, err := newHandler(ln.Addr(), "", []string{"testdata/mocks.json"}, stdout)
+	require.NoError(t, err)
+
+	s := &httptest.Server{Listener: ln, Config: &http.Server{Handler: handler}}
+	s.Start()
+	defer s.Close()
+	require.Equal(t, s.URL, handler.URL)
+
+	res, err := http.Get(s.URL + "/users/1")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, 200, res.StatusCode)
+	require.JSONEq(t, `{"id":1,"name":"foo"}`, string(body))
+
+	// Mocks without times nor persist are served more than once
+	for i := 0; i < 2; i++ {
+		res, err = http.Post(s.URL+"/users", "application/json", strings.NewReader(`{"name":"bar"}`))
+		require.NoError(t, err)
+		require.Equal(t, 201, res.StatusCode)
+		require.Equal(t, "/users/2", res.Header.Get("Location"))
+	}
+
+	res, err = http.Get(s.URL + "/unknown")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+	require.Contains(t, stdout.String(), "gock: cannot match any request: GET "+s.URL+"/unknown")
+	require.Len(t, handler.UnmatchedRequests(), 1)
+}
+
+func TestRunRequiresFiles(t *testing.T) {
+	err := run([]string{"-addr", "127.0.0.1:0"}, io.Discard)
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package httpmock
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Codec represents a Content-Encoding compression scheme, used to decompress
+// the matched request bodies and to compress the mock response bodies.
+type Codec interface {
+	// NewReader returns a reader decompressing the given compressed data.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+
+	// NewWriter returns a writer compressing the written data into the given writer.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+// codecsMutex is used to make the codecs registry thread-safe.
+var codecsMutex sync.RWMutex
+
+// codecs stores the registered compression codecs by Content-Encoding scheme.
+var codecs = map[string]Codec{
+	"gzip":    gzipCodec{},
+	"deflate": deflateCodec{},
+}
+
+// RegisterCodec registers the codec used for the given Content-Encoding scheme,
+// e.g. "br" or "zstd", replacing the existing one, if any.
+// It should be called before the mocks are used, e.g. in an init function.
+func RegisterCodec(scheme string, codec Codec) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	codecs[scheme] = codec
+}
+
+// codecFor returns the registered codec of the given scheme, if any.
+func codecFor(scheme string) Codec {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+	return codecs[scheme]
+}
+
+// compressBody compresses the given body using the codec of the given scheme.
+func compressBody(body []byte, scheme string) ([]byte, error) {
+	codec := codecFor(scheme)
+	if codec == nil {
+		return nil, fmt.Errorf("gock: unsupported compression scheme %q", scheme)
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := codec.NewWriter(buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// compressStream returns a reader compressing the given streamed body using the codec of the given scheme.
+// The data of every read is flushed, if the codec writer supports it, so a slow body is compressed
+// part by part instead of once fully read.
+func compressStream(body io.ReadCloser, scheme string) (io.ReadCloser, error) {
+	codec := codecFor(scheme)
+	if codec == nil {
+		return nil, fmt.Errorf("gock: unsupported compression scheme %q", scheme)
+	}
+
+	r := &compressReader{body: body}
+	writer, err := codec.NewWriter(&r.buf)
+	if err != nil {
+		return nil, err
+	}
+	r.writer = writer
+	return r, nil
+}
+
+// compressReader implements io.ReadCloser compressing the data read from the underlying body.
+type compressReader struct {
+	body   io.ReadCloser
+	writer io.WriteCloser
+	buf    bytes.Buffer
+	err    error
+}
+
+func (r *compressReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 && r.err == nil {
+		n, err := r.body.Read(p)
+		if n > 0 {
+			if _, werr := r.writer.Write(p[:n]); werr != nil {
+				return 0, werr
+			}
+			if flusher, ok := r.writer.(interface{ Flush() error }); ok {
+				if ferr := flusher.Flush(); ferr != nil {
+					return 0, ferr
+				}
+			}
+		}
+		if err == io.EOF {
+			// Closing the writer emits the compressed stream footer, if any
+			if r.err = r.writer.Close(); r.err == nil {
+				r.err = io.EOF
+			}
+		} else if err != nil {
+			r.err = err
+		}
+	}
+
+	if r.buf.Len() > 0 {
+		return r.buf.Read(p)
+	}
+	return 0, r.err
+}
+
+func (r *compressReader) Close() error {
+	return r.body.Close()
+}
+
+// gzipCodec implements the gzip compression scheme.
+type gzipCodec struct{}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+// deflateCodec implements the deflate compression scheme, which stands for zlib streams,
+// although raw deflate streams sent by some clients are decompressed as well.
+type deflateCodec struct{}
+
+func (deflateCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+func (deflateCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zlib.NewWriter(w), nil
+}
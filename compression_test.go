@@ -0,0 +1,172 @@
+package httpmock
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// reverseCodec is a fake codec reversing the body bytes, used to test custom codecs.
+type reverseCodec struct{}
+
+func (reverseCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	data, err := io.ReadAll(r)
+	return io.NopCloser(bytes.NewReader(reverse(data))), err
+}
+
+func (reverseCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return &reverseWriter{w: w}, nil
+}
+
+type reverseWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (rw *reverseWriter) Write(p []byte) (int, error) {
+	return rw.buf.Write(p)
+}
+
+func (rw *reverseWriter) Close() error {
+	_, err := rw.w.Write(reverse(rw.buf.Bytes()))
+	return err
+}
+
+func reverse(data []byte) []byte {
+	res := make([]byte, len(data))
+	for i, c := range data {
+		res[len(data)-1-i] = c
+	}
+	return res
+}
+
+func TestCompressBody(t *testing.T) {
+	t.Parallel()
+
+	for _, scheme := range []string{"gzip", "deflate"} {
+		compressed, err := compressBody([]byte("foo bar"), scheme)
+		require.NoError(t, err, scheme)
+		require.NotEqual(t, "foo bar", string(compressed), scheme)
+
+		reader, err := compressionReader(createReadCloser(compressed), scheme)
+		require.NoError(t, err, scheme)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err, scheme)
+		require.Equal(t, "foo bar", string(data), scheme)
+	}
+
+	_, err := compressBody([]byte("foo bar"), "unknown")
+	require.EqualError(t, err, `gock: unsupported compression scheme "unknown"`)
+}
+
+func TestDeflateCodecRawStream(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	w, _ := flate.NewWriter(buf, flate.DefaultCompression)
+	w.Write([]byte("foo bar"))
+	w.Close()
+
+	reader, err := deflateCodec{}.NewReader(buf)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "foo bar", string(data))
+}
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec("x-reverse", reverseCodec{})
+	t.Cleanup(func() {
+		codecsMutex.Lock()
+		defer codecsMutex.Unlock()
+		delete(codecs, "x-reverse")
+	})
+	require.NotNil(t, codecFor("x-reverse"))
+
+	s := Server(t)
+	New(s.URL).
+		Post("/").
+		Compression("x-reverse").
+		BodyString("foo bar").
+		Reply(200).
+		Compression("x-reverse").
+		BodyString("bar foo")
+
+	req, _ := http.NewRequest("POST", s.URL, strings.NewReader("rab oof"))
+	req.Header.Set("Content-Encoding", "x-reverse")
+	req.Header.Set("Content-Type", "text/plain")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "x-reverse", res.Header.Get("Content-Encoding"))
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, "oof rab", string(body))
+}
+
+func TestMockBodyMatchDeflate(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).Compression("deflate").BodyString("foo bar").Reply(201)
+
+	compressed, err := compressBody([]byte("foo bar"), "deflate")
+	require.NoError(t, err)
+	req, _ := http.NewRequest("POST", s.URL, bytes.NewReader(compressed))
+	req.Header.Set("Content-Encoding", "deflate")
+	req.Header.Set("Content-Type", "text/plain")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, res.StatusCode)
+}
+
+func TestResponseCompression(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	mocks := load(s.URL)
+	client := &http.Client{Transport: NewTransport(mocks)}
+	New(s.URL).Get("/deflate").Reply(200).Compression("deflate").BodyString("foo bar")
+	New(s.URL).Get("/gzip").Reply(200).Compression("gzip").BodyString("foo bar")
+
+	// The transport replies the compressed body as is
+	res, err := client.Get(s.URL + "/deflate")
+	require.NoError(t, err)
+	require.Equal(t, "deflate", res.Header.Get("Content-Encoding"))
+	reader, err := compressionReader(res.Body, "deflate")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(reader)
+	require.Equal(t, "foo bar", string(body))
+
+	// The server replies the compressed body, transparently decompressed by the client
+	res, err = http.Get(s.URL + "/gzip")
+	require.NoError(t, err)
+	require.True(t, res.Uncompressed)
+	body, _ = io.ReadAll(res.Body)
+	require.Equal(t, "foo bar", string(body))
+}
+
+func TestMockCompressedBodyAndJSONPath(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Post("/").
+		Compression("gzip").
+		BodyString(`{"user":{"id":42}}`).
+		MatchJSONPath("$.user.id", 42).
+		Reply(201)
+
+	compressed, err := compressBody([]byte(`{"user":{"id":42}}`), "gzip")
+	require.NoError(t, err)
+	req, _ := http.NewRequest("POST", s.URL, bytes.NewReader(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, res.StatusCode)
+}
@@ -0,0 +1,358 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Definition represents a declarative mock definition, usually loaded from a JSON or YAML file.
+// See the README for the documented file format.
+type Definition struct {
+	// Request stores the request fields to match.
+	Request RequestDefinition `json:"request" yaml:"request"`
+
+	// Response stores the response fields to reply.
+	Response ResponseDefinition `json:"response" yaml:"response"`
+
+	// Responses stores an optional sequence of responses, replied one per matched request.
+	// It takes precedence over Response.
+	Responses []ResponseDefinition `json:"responses,omitempty" yaml:"responses,omitempty"`
+
+	// Exhausted stores the behaviour once the responses sequence is consumed: repeat, fail or cycle.
+	Exhausted string `json:"exhausted,omitempty" yaml:"exhausted,omitempty"`
+}
+
+// RequestDefinition represents the request fields of a mock definition.
+type RequestDefinition struct {
+	Method      string            `json:"method,omitempty" yaml:"method,omitempty"`
+	Path        string            `json:"path,omitempty" yaml:"path,omitempty"`
+	Header      map[string]string `json:"header,omitempty" yaml:"header,omitempty"`
+	Query       map[string]string `json:"query,omitempty" yaml:"query,omitempty"`
+	PathParams  map[string]string `json:"pathParams,omitempty" yaml:"pathParams,omitempty"`
+	Cookies     map[string]string `json:"cookies,omitempty" yaml:"cookies,omitempty"`
+	BasicAuth   *BasicAuth        `json:"basicAuth,omitempty" yaml:"basicAuth,omitempty"`
+	Type        string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Compression string            `json:"compression,omitempty" yaml:"compression,omitempty"`
+	Body        string            `json:"body,omitempty" yaml:"body,omitempty"`
+	BodyFile    string            `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty"`
+	JSON        interface{}       `json:"json,omitempty" yaml:"json,omitempty"`
+	XML         string            `json:"xml,omitempty" yaml:"xml,omitempty"`
+	Times       int               `json:"times,omitempty" yaml:"times,omitempty"`
+	Persist     bool              `json:"persist,omitempty" yaml:"persist,omitempty"`
+}
+
+// BasicAuth represents the HTTP Basic Authentication credentials to match.
+type BasicAuth struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+}
+
+// ResponseDefinition represents the response fields of a mock definition.
+type ResponseDefinition struct {
+	Status      int               `json:"status,omitempty" yaml:"status,omitempty"`
+	Header      map[string]string `json:"header,omitempty" yaml:"header,omitempty"`
+	Cookies     map[string]string `json:"cookies,omitempty" yaml:"cookies,omitempty"`
+	Trailers    map[string]string `json:"trailers,omitempty" yaml:"trailers,omitempty"`
+	Type        string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Body        string            `json:"body,omitempty" yaml:"body,omitempty"`
+	BodyFile    string            `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty"`
+	JSON        interface{}       `json:"json,omitempty" yaml:"json,omitempty"`
+	XML         string            `json:"xml,omitempty" yaml:"xml,omitempty"`
+	Delay       Duration          `json:"delay,omitempty" yaml:"delay,omitempty"`
+	Error       string            `json:"error,omitempty" yaml:"error,omitempty"`
+	Compression string            `json:"compression,omitempty" yaml:"compression,omitempty"`
+	Chunks      []ChunkDefinition `json:"chunks,omitempty" yaml:"chunks,omitempty"`
+	Fault       Fault             `json:"fault,omitempty" yaml:"fault,omitempty"`
+}
+
+// ChunkDefinition represents a part of a streamed response body in a mock definition file.
+type ChunkDefinition struct {
+	Data  string   `json:"data" yaml:"data"`
+	Delay Duration `json:"delay,omitempty" yaml:"delay,omitempty"`
+}
+
+// Duration is a time.Duration which is decoded from strings such as "150ms" or "2s".
+type Duration time.Duration
+
+// UnmarshalJSON decodes the duration from a JSON string or number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.set(value)
+}
+
+// UnmarshalYAML decodes the duration from a YAML string or number of nanoseconds.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	return d.set(value)
+}
+
+func (d *Duration) set(value interface{}) error {
+	switch value := value.(type) {
+	case string:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*d = Duration(duration)
+	case float64:
+		*d = Duration(value)
+	case int:
+		*d = Duration(value)
+	default:
+		return fmt.Errorf("gock: invalid duration %v", value)
+	}
+	return nil
+}
+
+// LoadMocks registers the mocks defined in the given file, or in every
+// JSON and YAML file of the given directory, for the given server.
+// The test fails if the definitions cannot be read.
+func LoadMocks(t *testing.T, server *httptest.Server, path string) []*Request {
+	t.Helper()
+
+	files := []string{path}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.json", "*.yaml", "*.yml"} {
+			matches, _ := filepath.Glob(filepath.Join(path, pattern))
+			files = append(files, matches...)
+		}
+	}
+
+	reqs := []*Request{}
+	for _, file := range files {
+		defs, err := ReadDefinitions(file)
+		if err != nil {
+			t.Fatalf("httpmock: cannot load mocks from %s: %v", file, err)
+			return nil
+		}
+		for _, def := range defs {
+			reqs = append(reqs, def.Mock(server.URL))
+		}
+	}
+	return reqs
+}
+
+// ReadDefinitions reads the mock definitions stored in the given JSON or YAML file,
+// depending on its extension. The file may contain a single definition or a list of definitions.
+// Relative body file paths are resolved from the file directory.
+func ReadDefinitions(path string) ([]*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []*Definition
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		defs, err = decodeYAMLDefinitions(data)
+	default:
+		defs, err = decodeJSONDefinitions(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	for _, def := range defs {
+		if err := def.resolve(dir); err != nil {
+			return nil, err
+		}
+	}
+	return defs, nil
+}
+
+func decodeJSONDefinitions(data []byte) ([]*Definition, error) {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] != '[' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+
+	var defs []*Definition
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+func decodeYAMLDefinitions(data []byte) ([]*Definition, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	var defs []*Definition
+	if node.Content[0].Kind == yaml.SequenceNode {
+		err := node.Decode(&defs)
+		return defs, err
+	}
+
+	def := &Definition{}
+	err := node.Decode(def)
+	return []*Definition{def}, err
+}
+
+// resolve makes the definition body file paths absolute and checks they exist,
+// and checks the exhausted mode and faults are supported.
+func (d *Definition) resolve(dir string) error {
+	paths := []*string{&d.Request.BodyFile, &d.Response.BodyFile}
+	faults := []Fault{d.Response.Fault}
+	for i := range d.Responses {
+		paths = append(paths, &d.Responses[i].BodyFile)
+		faults = append(faults, d.Responses[i].Fault)
+	}
+
+	for _, fault := range faults {
+		if fault != "" && !fault.valid() {
+			return fmt.Errorf("gock: invalid fault %q", fault)
+		}
+	}
+
+	for _, path := range paths {
+		if *path == "" {
+			continue
+		}
+		if !filepath.IsAbs(*path) {
+			*path = filepath.Join(dir, *path)
+		}
+		if _, err := os.Stat(*path); err != nil {
+			return err
+		}
+	}
+
+	switch d.Exhausted {
+	case "", "repeat", "fail", "cycle":
+		return nil
+	default:
+		return fmt.Errorf("gock: invalid exhausted mode %q", d.Exhausted)
+	}
+}
+
+// Mock registers a new mock for the given URL based on the definition.
+func (d *Definition) Mock(uri string) *Request {
+	req := New(uri)
+
+	dreq := d.Request
+	if dreq.Method != "" || dreq.Path != "" {
+		req.method(dreq.Method, dreq.Path)
+	}
+	req.MatchHeaders(dreq.Header)
+	req.MatchParams(dreq.Query)
+	for key, value := range dreq.PathParams {
+		req.PathParam(key, value)
+	}
+	for name, value := range dreq.Cookies {
+		req.MatchCookie(name, value)
+	}
+	if dreq.BasicAuth != nil {
+		req.BasicAuth(dreq.BasicAuth.Username, dreq.BasicAuth.Password)
+	}
+	if dreq.Type != "" {
+		req.MatchType(dreq.Type)
+	}
+	if dreq.Compression != "" {
+		req.Compression(dreq.Compression)
+	}
+	if dreq.Body != "" {
+		req.BodyString(dreq.Body)
+	}
+	if dreq.BodyFile != "" {
+		req.File(dreq.BodyFile)
+	}
+	if dreq.JSON != nil {
+		req.JSON(dreq.JSON)
+	}
+	if dreq.XML != "" {
+		req.XML(dreq.XML)
+	}
+	if dreq.Times > 0 {
+		req.Times(dreq.Times)
+	}
+	if dreq.Persist {
+		req.Persist()
+	}
+
+	switch d.Exhausted {
+	case "fail":
+		req.WhenExhausted(SequenceFail)
+	case "cycle":
+		req.WhenExhausted(SequenceCycle)
+	}
+
+	if len(d.Responses) == 0 {
+		d.Response.apply(req.Response)
+		return req
+	}
+	for i, dres := range d.Responses {
+		if i > 0 {
+			req.then()
+		}
+		dres.apply(req.current())
+	}
+	return req
+}
+
+// apply defines the given mock response based on the definition.
+func (d ResponseDefinition) apply(res *Response) {
+	status := d.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	res.Status(status)
+	res.SetHeaders(d.Header)
+	for name, value := range d.Cookies {
+		res.SetCookie(&http.Cookie{Name: name, Value: value})
+	}
+	for key, value := range d.Trailers {
+		res.Trailer(key, value)
+	}
+	if d.Type != "" {
+		res.Type(d.Type)
+	}
+	if d.Body != "" {
+		res.BodyString(d.Body)
+	}
+	if d.BodyFile != "" {
+		res.File(d.BodyFile)
+	}
+	if d.JSON != nil {
+		res.JSON(d.JSON)
+	}
+	if d.XML != "" {
+		res.XML(d.XML)
+	}
+	if d.Compression != "" {
+		res.Compression(d.Compression)
+	}
+	for _, chunk := range d.Chunks {
+		res.ChunkString(chunk.Data, time.Duration(chunk.Delay))
+	}
+	if d.Delay > 0 {
+		res.Delay(time.Duration(d.Delay))
+	}
+	if d.Error != "" {
+		res.SetError(errors.New(d.Error))
+	}
+	if d.Fault != "" {
+		res.Fault(d.Fault)
+	}
+}
@@ -0,0 +1,180 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDefinitions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	single := filepath.Join(dir, "single.json")
+	require.NoError(t, os.WriteFile(single, []byte(`{"request": {"path": "/foo"}, "response": {"status": 204}}`), 0o644))
+
+	defs, err := ReadDefinitions(single)
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	require.Equal(t, "/foo", defs[0].Request.Path)
+	require.Equal(t, 204, defs[0].Response.Status)
+
+	_, err = ReadDefinitions(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestDefinitionMock(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	def := &Definition{
+		Request: RequestDefinition{
+			Method: "POST",
+			Path:   "/users",
+			Header: map[string]string{"Authorization": "^Bearer .+$"},
+			Query:  map[string]string{"notify": "true"},
+			JSON:   map[string]interface{}{"name": "foo"},
+		},
+		Response: ResponseDefinition{
+			Status: 201,
+			Header: map[string]string{"Location": "/users/1"},
+			JSON:   map[string]interface{}{"id": 1},
+		},
+	}
+	def.Mock(s.URL)
+
+	req, _ := http.NewRequest("POST", s.URL+"/users?notify=true", strings.NewReader(`{"name":"foo"}`))
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, res.StatusCode)
+	require.Equal(t, "/users/1", res.Header.Get("Location"))
+	body, _ := io.ReadAll(res.Body)
+	require.JSONEq(t, `{"id":1}`, string(body))
+	require.True(t, IsDone(t))
+}
+
+func TestReadDefinitionsYAML(t *testing.T) {
+	t.Parallel()
+
+	defs, err := ReadDefinitions("testdata/mocks/users.yaml")
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	require.Equal(t, "GET", defs[0].Request.Method)
+	require.Equal(t, map[string]interface{}{"id": 1, "name": "foo"}, defs[0].Response.JSON)
+	require.Len(t, defs[1].Responses, 2)
+	require.Equal(t, filepath.Join("testdata", "mocks", "bodies", "user.json"), defs[1].Responses[1].BodyFile)
+
+	defs, err = ReadDefinitions("testdata/mocks/errors.json")
+	require.NoError(t, err)
+	require.Equal(t, Duration(10*time.Millisecond), defs[0].Response.Delay)
+}
+
+func TestDefinitionChunks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stream.yaml")
+	data := `
+request:
+  path: /stream
+response:
+  chunks:
+    - data: foo
+    - data: bar
+      delay: 10ms
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	s := Server(t)
+	LoadMocks(t, s, path)
+
+	res, err := http.Get(s.URL + "/stream")
+	require.NoError(t, err)
+	require.Equal(t, []string{"chunked"}, res.TransferEncoding)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, "foobar", string(body))
+}
+
+func TestDefinitionFault(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	def := &Definition{
+		Request:  RequestDefinition{Path: "/foo"},
+		Response: ResponseDefinition{Body: "foo bar", Fault: FaultTruncatedBody},
+	}
+	def.Mock(s.URL)
+
+	res, err := http.Get(s.URL + "/foo")
+	require.NoError(t, err)
+	_, err = io.ReadAll(res.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestReadDefinitionsMissingBodyFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mocks.yml")
+	require.NoError(t, os.WriteFile(path, []byte("request:\n  path: /foo\nresponse:\n  bodyFile: missing.json\n"), 0o644))
+
+	_, err := ReadDefinitions(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadDefinitionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for data, msg := range map[string]string{
+		`{"request": {"path": "/foo"}, "exhausted": "bogus"}`:           `gock: invalid exhausted mode "bogus"`,
+		`{"request": {"path": "/foo"}, "response": {"delay": true}}`:    `gock: invalid duration true`,
+		`{"request": {"path": "/foo"}, "response": {"fault": "bogus"}}`: `gock: invalid fault "bogus"`,
+	} {
+		path := filepath.Join(dir, "mocks.json")
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+		_, err := ReadDefinitions(path)
+		require.EqualError(t, err, msg)
+	}
+}
+
+func TestLoadMocks(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	reqs := LoadMocks(t, s, "testdata/mocks")
+	require.Len(t, reqs, 3)
+
+	req, _ := http.NewRequest("GET", s.URL+"/users/1", nil)
+	req.Header.Set("Authorization", "Bearer abc")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "abc", res.Header.Get("X-Request-Id"))
+	body, _ := io.ReadAll(res.Body)
+	require.JSONEq(t, `{"id":1,"name":"foo"}`, string(body))
+
+	for _, status := range []int{503, 201} {
+		res, err = http.Post(s.URL+"/users", "application/json", strings.NewReader(`{"name":"bar"}`))
+		require.NoError(t, err)
+		require.Equal(t, status, res.StatusCode)
+	}
+	body, _ = io.ReadAll(res.Body)
+	require.JSONEq(t, `{"id":2,"name":"bar"}`, string(body))
+
+	res, err = http.Get(s.URL + "/slow?page=1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+	body, _ = io.ReadAll(res.Body)
+	require.Equal(t, "connection reset", string(body))
+	require.True(t, IsDone(t))
+}
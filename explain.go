@@ -0,0 +1,258 @@
+package httpmock
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// MaxClosestMocks defines how many closest mocks are reported by MatchError.
+var MaxClosestMocks = 3
+
+// MatchFailure represents a matcher function which rejected a request.
+type MatchFailure struct {
+	// Matcher stores the matcher function name.
+	Matcher string
+
+	// Reason stores a human readable explanation of the mismatch, if available.
+	Reason string
+}
+
+// MatchResult represents the outcome of matching a request against a mock, recorded while matching it.
+// Matching stops at the first matcher function rejecting the request.
+type MatchResult struct {
+	// Mock stores the mock the request was matched against.
+	Mock Mock
+
+	// Passed stores the names of the matcher functions which accepted the request.
+	Passed []string
+
+	// Failed stores the matcher function which rejected the request, if any.
+	Failed []MatchFailure
+
+	// Total stores the number of matcher functions of the mock.
+	Total int
+}
+
+// Matches returns true if no matcher function rejected the request.
+func (r *MatchResult) Matches() bool {
+	return len(r.Failed) == 0
+}
+
+// String returns a human readable representation of the match result.
+func (r *MatchResult) String() string {
+	buf := &strings.Builder{}
+	if r.Mock != nil {
+		fmt.Fprintf(buf, "%s (%d/%d matchers passed)\n", mockTarget(r.Mock), len(r.Passed), r.Total)
+	}
+	for _, name := range r.Passed {
+		fmt.Fprintf(buf, "    + %s\n", name)
+	}
+	for _, failure := range r.Failed {
+		if failure.Reason == "" {
+			fmt.Fprintf(buf, "    - %s\n", failure.Matcher)
+			continue
+		}
+		fmt.Fprintf(buf, "    - %s: %s\n", failure.Matcher, failure.Reason)
+	}
+	return buf.String()
+}
+
+// MatchError is returned when a request cannot be matched by any mock.
+// It wraps ErrCannotMatch and explains the closest mocks.
+type MatchError struct {
+	// Request stores the unmatched request.
+	Request *http.Request
+
+	// Closest stores the pending mocks results, ranked by the number of passed matchers.
+	Closest []*MatchResult
+}
+
+// Error returns the ErrCannotMatch message followed by the closest mocks explanation.
+func (e *MatchError) Error() string {
+	buf := &strings.Builder{}
+	buf.WriteString(ErrCannotMatch.Error())
+	if e.Request != nil && e.Request.URL != nil {
+		fmt.Fprintf(buf, ": %s %s", e.Request.Method, e.Request.URL)
+	}
+	if len(e.Closest) == 0 {
+		return buf.String()
+	}
+
+	buf.WriteString("\n\nclosest mocks:\n")
+	for i, result := range e.Closest {
+		fmt.Fprintf(buf, "  %d. %s", i+1, result)
+	}
+	return buf.String()
+}
+
+// Unwrap returns ErrCannotMatch.
+func (e *MatchError) Unwrap() error {
+	return ErrCannotMatch
+}
+
+// MatchResult matches the given http.Request with a mock request like Match,
+// recording which matcher functions accepted the request and the one which rejected it.
+func (m *MockMatcher) MatchResult(req *http.Request, ereq *Request) (*MatchResult, error) {
+	result := &MatchResult{Total: len(m.Matchers)}
+
+	for _, matcher := range m.Matchers {
+		matches, err := matcher(req, ereq)
+		if err != nil {
+			return result, err
+		}
+		name := funcName(matcher)
+		if matches {
+			result.Passed = append(result.Passed, name)
+			continue
+		}
+
+		failure := MatchFailure{Matcher: name}
+		if reason, ok := reasons[name]; ok {
+			failure.Reason = reason(req, ereq)
+		}
+		result.Failed = append(result.Failed, failure)
+		break
+	}
+
+	return result, nil
+}
+
+// newMatchError creates a new MatchError with the closest of the given match results.
+func newMatchError(req *http.Request, results []*MatchResult) *MatchError {
+	closest := append([]*MatchResult{}, results...)
+	sort.SliceStable(closest, func(i, j int) bool {
+		return len(closest[i].Passed) > len(closest[j].Passed)
+	})
+	if len(closest) > MaxClosestMocks {
+		closest = closest[:MaxClosestMocks]
+	}
+	return &MatchError{Request: req, Closest: closest}
+}
+
+// reasons stores the functions used to explain why a built-in matcher rejected a request.
+var reasons = map[string]func(*http.Request, *Request) string{
+	"MatchMethod": func(req *http.Request, ereq *Request) string {
+		return fmt.Sprintf("method %q, expected %q", req.Method, ereq.Method)
+	},
+	"MatchScheme": func(req *http.Request, ereq *Request) string {
+		return fmt.Sprintf("scheme %q, expected %q", req.URL.Scheme, ereq.URLStruct.Scheme)
+	},
+	"MatchHost": func(req *http.Request, ereq *Request) string {
+		return fmt.Sprintf("host %q, expected %q", req.URL.Host, ereq.URLStruct.Host)
+	},
+	"MatchPath": func(req *http.Request, ereq *Request) string {
+		return fmt.Sprintf("path %q, expected %q", req.URL.Path, ereq.URLStruct.Path)
+	},
+	"MatchHeaders": func(req *http.Request, ereq *Request) string {
+		for key, value := range ereq.Header {
+			sub := &Request{Header: http.Header{key: value}}
+			if ok, _ := MatchHeaders(req, sub); !ok {
+				return fmt.Sprintf("header %q is %q, expected %q", key, req.Header.Get(key), value[0])
+			}
+		}
+		return ""
+	},
+	"MatchCookies": func(req *http.Request, ereq *Request) string {
+		for _, cookie := range ereq.Cookies {
+			sub := &Request{Cookies: []*http.Cookie{cookie}}
+			if ok, _ := MatchCookies(req, sub); !ok {
+				return fmt.Sprintf("cookie %q does not match %q", cookie.Name, cookie.Value)
+			}
+		}
+		return ""
+	},
+	"MatchQueryParams": func(req *http.Request, ereq *Request) string {
+		for key, value := range ereq.URLStruct.Query() {
+			sub := &Request{URLStruct: &url.URL{RawQuery: url.Values{key: value[:1]}.Encode()}}
+			if ok, _ := MatchQueryParams(req, sub); !ok {
+				return fmt.Sprintf("query param %q is %q, expected %q", key, req.URL.Query().Get(key), value[0])
+			}
+		}
+		return ""
+	},
+	"MatchPathParams": func(req *http.Request, ereq *Request) string {
+		for key, value := range ereq.PathParams {
+			sub := &Request{PathParams: map[string]string{key: value}}
+			if ok, _ := MatchPathParams(req, sub); !ok {
+				return fmt.Sprintf("path param %q, expected %q", key, value)
+			}
+		}
+		return ""
+	},
+	"MatchBody": func(req *http.Request, ereq *Request) string {
+		return "body does not match"
+	},
+	"MatchForm": func(req *http.Request, ereq *Request) string {
+		for key, value := range ereq.FormFields {
+			sub := &Request{FormFields: map[string]string{key: value}, CompressionScheme: ereq.CompressionScheme}
+			if ok, _ := MatchForm(req, sub); !ok {
+				return fmt.Sprintf("form field %q does not match %q", key, value)
+			}
+		}
+		for _, file := range ereq.MultipartFiles {
+			sub := &Request{MultipartFiles: []*MultipartFile{file}, CompressionScheme: ereq.CompressionScheme}
+			if ok, _ := MatchForm(req, sub); !ok {
+				return fmt.Sprintf("multipart file %q does not match filename %q and content %q", file.Field, file.Filename, file.Content)
+			}
+		}
+		return ""
+	},
+	"MatchGraphQL": func(req *http.Request, ereq *Request) string {
+		bodies, _ := readGraphQL(req, ereq)
+		if len(bodies) == 0 {
+			return "body is not a GraphQL request"
+		}
+		body := bodies[0]
+		switch {
+		case ereq.GraphQLOperation != "" && graphQLOperationName(body) != ereq.GraphQLOperation:
+			return fmt.Sprintf("GraphQL operation %q, expected %q", graphQLOperationName(body), ereq.GraphQLOperation)
+		case ereq.GraphQLQuery != "" && normalizeGraphQL(body.Query) != ereq.GraphQLQuery:
+			return "GraphQL query does not match"
+		default:
+			return "GraphQL variables do not match"
+		}
+	},
+	"MatchJSONPaths": func(req *http.Request, ereq *Request) string {
+		for path, value := range ereq.JSONPaths {
+			if _, err := parseJSONPath(path); err != nil {
+				return err.Error()
+			}
+			sub := &Request{JSONPaths: map[string]interface{}{path: value}, JSONPartial: ereq.JSONPartial, ArrayOrderIgnored: ereq.ArrayOrderIgnored, CompressionScheme: ereq.CompressionScheme}
+			if ok, _ := MatchJSONPaths(req, sub); !ok {
+				return fmt.Sprintf("JSON path %q does not match %v", path, value)
+			}
+		}
+		return ""
+	},
+}
+
+// mockTarget returns the method and URL expected by the given mock, e.g. GET http://foo.com/bar.
+func mockTarget(mock Mock) string {
+	ereq := mock.Request()
+	method := ereq.Method
+	if method == "" {
+		method = "*"
+	}
+	return fmt.Sprintf("%s %s", method, ereq.URLStruct)
+}
+
+// funcName returns the short name of the given function, e.g. MatchHeaders.
+func funcName(fn interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+	name := f.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,111 @@
+package httpmock
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockMatcherMatchResult(t *testing.T) {
+	t.Parallel()
+
+	ereq := NewRequest()
+	ereq.URLStruct, _ = url.Parse("http://foo.com/bar")
+	ereq.Method = "GET"
+	ereq.MatchHeader("X-Api-Key", "^secret$")
+	ereq.MatchParam("page", "1")
+
+	u, _ := url.Parse("http://foo.com/bar?page=2")
+	req := &http.Request{Method: "GET", URL: u, Header: http.Header{"X-Api-Key": []string{"public"}}}
+
+	// Matching stops at the first rejecting matcher
+	result, err := NewBasicMatcher().MatchResult(req, ereq)
+	require.NoError(t, err)
+	require.False(t, result.Matches())
+	require.Equal(t, 8, result.Total)
+	require.Equal(t, []string{"MatchMethod", "MatchScheme", "MatchHost", "MatchPath"}, result.Passed)
+	require.Equal(t, []MatchFailure{
+		{Matcher: "MatchHeaders", Reason: `header "X-Api-Key" is "public", expected "^secret$"`},
+	}, result.Failed)
+
+	req.Header.Set("X-Api-Key", "secret")
+	result, err = NewBasicMatcher().MatchResult(req, ereq)
+	require.NoError(t, err)
+	require.Equal(t, []MatchFailure{
+		{Matcher: "MatchQueryParams", Reason: `query param "page" is "2", expected "1"`},
+	}, result.Failed)
+}
+
+func TestMatchErrorRunsMatchersOnce(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	calls := 0
+	New(s.URL).Get("/foo").AddMatcher(func(req *http.Request, ereq *Request) (bool, error) {
+		calls++
+		return false, nil
+	}).Reply(200)
+
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	_, err := client.Get(s.URL + "/foo")
+	require.ErrorIs(t, err, ErrCannotMatch)
+	require.Equal(t, 1, calls)
+
+	var merr *MatchError
+	require.ErrorAs(t, err, &merr)
+	require.Len(t, merr.Closest, 1)
+	require.Len(t, merr.Closest[0].Passed, 12)
+	require.Len(t, merr.Closest[0].Failed, 1)
+}
+
+func TestMatchErrorClosestMocks(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).Post("/accounts").Reply(201)
+	New(s.URL).Get("/users").MatchHeader("X-Api-Key", "secret").Reply(200)
+	mocks := load(s.URL)
+
+	u, _ := url.Parse(s.URL + "/users")
+	req := &http.Request{Method: "GET", URL: u, Header: make(http.Header)}
+	_, err := NewTransport(mocks).RoundTrip(req)
+	require.ErrorIs(t, err, ErrCannotMatch)
+
+	var merr *MatchError
+	require.True(t, errors.As(err, &merr))
+	require.Len(t, merr.Closest, 2)
+	require.Equal(t, "GET", merr.Closest[0].Mock.Request().Method)
+	require.Equal(t, "MatchHeaders", merr.Closest[0].Failed[0].Matcher)
+	require.Equal(t, "MatchMethod", merr.Closest[1].Failed[0].Matcher)
+	require.Contains(t, err.Error(), "closest mocks:")
+	require.Contains(t, err.Error(), `- MatchHeaders: header "X-Api-Key" is "", expected "secret"`)
+}
+
+func TestMatchErrorServerBody(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).Get("/foo").Reply(200)
+
+	res, err := http.Get(s.URL + "/bar")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+
+	body, _ := io.ReadAll(res.Body)
+	require.Contains(t, string(body), `- MatchPath: path "/bar", expected "/foo"`)
+}
+
+func TestMatchErrorWithoutMocks(t *testing.T) {
+	t.Parallel()
+
+	err := &MatchError{}
+	require.Equal(t, ErrCannotMatch.Error(), err.Error())
+
+	u, _ := url.Parse("http://foo.com/bar")
+	err = &MatchError{Request: &http.Request{Method: "GET", URL: u}}
+	require.Equal(t, ErrCannotMatch.Error()+": GET http://foo.com/bar", err.Error())
+}
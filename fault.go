@@ -0,0 +1,200 @@
+package httpmock
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+)
+
+// Fault represents a network failure simulated instead of, or while, replying a mock response.
+type Fault string
+
+const (
+	// FaultEmptyResponse closes the connection without replying any response.
+	FaultEmptyResponse Fault = "empty_response"
+
+	// FaultConnectionReset resets the connection in the middle of the response body.
+	FaultConnectionReset Fault = "connection_reset"
+
+	// FaultTruncatedBody closes the connection after sending half of the response body,
+	// so fewer bytes than the Content-Length are received.
+	FaultTruncatedBody Fault = "truncated_body"
+
+	// FaultHang never replies, until the request is canceled, e.g. by the client timeout.
+	FaultHang Fault = "hang"
+
+	// FaultMalformedResponse replies data which is not a valid HTTP response.
+	FaultMalformedResponse Fault = "malformed_response"
+)
+
+// faults stores the supported network faults.
+var faults = []Fault{FaultEmptyResponse, FaultConnectionReset, FaultTruncatedBody, FaultHang, FaultMalformedResponse}
+
+// valid returns true if the fault is a supported one.
+func (f Fault) valid() bool {
+	for _, fault := range faults {
+		if f == fault {
+			return true
+		}
+	}
+	return false
+}
+
+// malformedResponse is the invalid HTTP response sent by the mock server for FaultMalformedResponse.
+const malformedResponse = "HTTP/1.1 ABC Malformed\r\n\r\n"
+
+// FaultError is the error returned by Transport, or by the response body reads,
+// when simulating a network fault. It wraps the error a real connection would cause,
+// e.g. io.EOF, io.ErrUnexpectedEOF or syscall.ECONNRESET, so errors.Is works as usual.
+type FaultError struct {
+	// Fault stores the simulated fault.
+	Fault Fault
+
+	// Err stores the simulated network error.
+	Err error
+}
+
+func (e *FaultError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FaultError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout returns true if the simulated network error is a timeout, e.g. the request
+// context deadline for FaultHang, so FaultError satisfies net.Error like real timeouts.
+func (e *FaultError) Timeout() bool {
+	var timeout interface{ Timeout() bool }
+	return errors.As(e.Err, &timeout) && timeout.Timeout()
+}
+
+// Temporary returns false, since simulated faults are never retried.
+func (e *FaultError) Temporary() bool {
+	return false
+}
+
+// Fault defines the network fault to simulate instead of replying the response normally.
+// Through Transport faults are returned as *FaultError errors, from RoundTrip or from the response
+// body reads, while the Server handler fails the underlying connection, e.g.
+//
+//	New(url).Get("/foo").Reply(200).BodyString("foo bar").Fault(FaultConnectionReset)
+func (r *Response) Fault(fault Fault) *Response {
+	r.NetworkFault = fault
+	return r
+}
+
+// applyFault applies the given fault to the given response, returning the error to reply instead, if any.
+func applyFault(req *http.Request, fault Fault, res *http.Response) (*http.Response, error) {
+	switch fault {
+	case FaultEmptyResponse:
+		return nil, &FaultError{Fault: fault, Err: io.EOF}
+
+	case FaultMalformedResponse:
+		return nil, &FaultError{Fault: fault, Err: errors.New(`malformed HTTP status code "ABC"`)}
+
+	case FaultHang:
+		<-req.Context().Done()
+		return nil, &FaultError{Fault: fault, Err: req.Context().Err()}
+
+	case FaultConnectionReset:
+		err := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		res.Body = newFaultReader(res.Body, faultLimit(res), &FaultError{Fault: fault, Err: err})
+
+	case FaultTruncatedBody:
+		res.Body = newFaultReader(res.Body, faultLimit(res), &FaultError{Fault: fault, Err: io.ErrUnexpectedEOF})
+	}
+	return res, nil
+}
+
+// faultLimit returns the number of body bytes sent before a connection fault: half of the body
+// if its length is known, or -1 to cut off the first read data otherwise, e.g. for chunked bodies.
+func faultLimit(res *http.Response) int64 {
+	if res.ContentLength < 0 {
+		return -1
+	}
+	return res.ContentLength / 2
+}
+
+// faultReader implements io.ReadCloser reading the given body up to the given limit and then
+// failing with the given error. If the limit is negative, since the body length is unknown,
+// only the first half of the first read data is returned.
+type faultReader struct {
+	body  io.ReadCloser
+	limit int64
+	err   error
+}
+
+// newFaultReader creates a new faultReader.
+func newFaultReader(body io.ReadCloser, limit int64, err error) *faultReader {
+	return &faultReader{body: body, limit: limit, err: err}
+}
+
+func (r *faultReader) Read(p []byte) (int, error) {
+	if r.limit == 0 {
+		return 0, r.err
+	}
+	if r.limit > 0 && int64(len(p)) > r.limit {
+		p = p[:r.limit]
+	}
+
+	n, err := r.body.Read(p)
+	switch {
+	case r.limit < 0 && n > 0:
+		n = (n + 1) / 2
+		r.limit = 0
+	case r.limit > 0:
+		r.limit -= int64(n)
+	}
+	if err == io.EOF {
+		r.limit = 0
+		if n == 0 {
+			return 0, r.err
+		}
+		err = nil
+	}
+	return n, err
+}
+
+func (r *faultReader) Close() error {
+	return r.body.Close()
+}
+
+// abort fails the connection of the given response writer simulating the given fault.
+// The response data written so far must be flushed before. The connection is hijacked when possible, otherwise the handler is aborted, which closes
+// HTTP/1 connections and resets HTTP/2 streams.
+func abort(rw http.ResponseWriter, fault Fault) {
+	if fault == FaultHang {
+		// The request has already been canceled by the client
+		return
+	}
+
+	hijacker, ok := rw.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	defer conn.Close()
+
+	switch fault {
+	case FaultMalformedResponse:
+		buf.WriteString(malformedResponse)
+		buf.Flush()
+
+	case FaultConnectionReset:
+		// Discard the unsent data and send a RST instead of a FIN
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			conn = tlsConn.NetConn()
+		}
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			tcpConn.SetLinger(0)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package httpmock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultTransport(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/empty").Reply(200).BodyString("foo bar").Fault(FaultEmptyResponse)
+	New(s.URL).Get("/malformed").Reply(200).Fault(FaultMalformedResponse)
+	New(s.URL).Get("/reset").Reply(200).BodyString("foo bar").Fault(FaultConnectionReset)
+	New(s.URL).Get("/truncated").Reply(200).BodyString("foo bar").Fault(FaultTruncatedBody)
+	New(s.URL).Get("/chunked").Reply(200).ChunkString("foo", 0).ChunkString("bar", 0).Fault(FaultConnectionReset)
+
+	_, err := client.Get(s.URL + "/empty")
+	require.ErrorIs(t, err, io.EOF)
+	var fault *FaultError
+	require.ErrorAs(t, err, &fault)
+	require.Equal(t, FaultEmptyResponse, fault.Fault)
+
+	_, err = client.Get(s.URL + "/malformed")
+	require.ErrorContains(t, err, "malformed HTTP status code")
+
+	res, err := client.Get(s.URL + "/reset")
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.ErrorIs(t, err, syscall.ECONNRESET)
+	require.Equal(t, "foo", string(body))
+
+	res, err = client.Get(s.URL + "/truncated")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), res.ContentLength)
+	body, err = io.ReadAll(res.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, "foo", string(body))
+
+	res, err = client.Get(s.URL + "/chunked")
+	require.NoError(t, err)
+	body, err = io.ReadAll(res.Body)
+	require.ErrorIs(t, err, syscall.ECONNRESET)
+	require.Equal(t, "fo", string(body))
+}
+
+func TestFaultTransportHang(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL)), Timeout: 50 * time.Millisecond}
+	New(s.URL).Get("/hang").Reply(200).Fault(FaultHang)
+
+	start := time.Now()
+	_, err := client.Get(s.URL + "/hang")
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout())
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+}
+
+func TestFaultErrorTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Whether the client timer or the request context deadline fires first, the error is a timeout
+	var netErr net.Error = &FaultError{Fault: FaultHang, Err: context.DeadlineExceeded}
+	require.True(t, netErr.Timeout())
+	netErr = &url.Error{Op: "Get", URL: "http://foo.com", Err: &FaultError{Fault: FaultHang, Err: context.DeadlineExceeded}}
+	require.True(t, netErr.Timeout())
+
+	require.False(t, (&FaultError{Fault: FaultHang, Err: context.Canceled}).Timeout())
+	require.False(t, (&FaultError{Fault: FaultTruncatedBody, Err: io.ErrUnexpectedEOF}).Timeout())
+}
+
+func TestFaultServer(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/empty").Reply(200).BodyString("foo bar").Fault(FaultEmptyResponse)
+	New(s.URL).Get("/malformed").Reply(200).Fault(FaultMalformedResponse)
+	New(s.URL).Get("/reset").Reply(200).BodyString(strings.Repeat("foo bar ", 1024)).Fault(FaultConnectionReset)
+	New(s.URL).Get("/truncated").Reply(200).BodyString("foo bar").Fault(FaultTruncatedBody)
+	New(s.URL).Get("/chunked").Reply(200).ChunkString("foo", 0).ChunkString("bar", 0).Fault(FaultTruncatedBody)
+	New(s.URL).Get("/trailers").Reply(200).BodyString("foo bar").Trailer("X-Checksum", "abc").Fault(FaultTruncatedBody)
+
+	// Disable retries of idempotent requests on closed connections
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	_, err := client.Get(s.URL + "/empty")
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = client.Get(s.URL + "/malformed")
+	require.ErrorContains(t, err, `malformed HTTP status code "ABC"`)
+
+	res, err := client.Get(s.URL + "/reset")
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.Error(t, err)
+	require.Less(t, len(body), 8*1024)
+
+	res, err = client.Get(s.URL + "/truncated")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), res.ContentLength)
+	body, err = io.ReadAll(res.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, "foo", string(body))
+
+	res, err = client.Get(s.URL + "/chunked")
+	require.NoError(t, err)
+	body, err = io.ReadAll(res.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, "fo", string(body))
+
+	res, err = client.Get(s.URL + "/trailers")
+	require.NoError(t, err)
+	body, err = io.ReadAll(res.Body)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, "foo ", string(body))
+	require.Empty(t, res.Trailer.Get("X-Checksum"))
+}
+
+func TestFaultServerHang(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/hang").Reply(200).Fault(FaultHang)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/hang", nil)
+	_, err := http.DefaultClient.Do(req)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestFaultReader(t *testing.T) {
+	t.Parallel()
+
+	fault := errors.New("fault")
+	reader := newFaultReader(createReadCloser([]byte("foo bar")), -1, fault)
+	body, err := io.ReadAll(reader)
+	require.Equal(t, fault, err)
+	require.Equal(t, "foo ", string(body))
+
+	reader = newFaultReader(newChunkReader(context.Background(), []BodyChunk{{Data: []byte("foo")}, {Data: []byte("bar")}}), -1, fault)
+	body, err = io.ReadAll(reader)
+	require.Equal(t, fault, err)
+	require.Equal(t, "fo", string(body))
+
+	reader = newFaultReader(createReadCloser([]byte("foo bar")), 5, fault)
+	body, err = io.ReadAll(reader)
+	require.Equal(t, fault, err)
+	require.Equal(t, "foo b", string(body))
+	require.NoError(t, reader.Close())
+}
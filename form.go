@@ -0,0 +1,109 @@
+package httpmock
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// MultipartFile represents a file of a multipart form body to match.
+type MultipartFile struct {
+	// Field stores the form field name the file is uploaded in.
+	Field string
+
+	// Filename stores the regular expression matching the file name, an empty one matches any.
+	Filename string
+
+	// Content stores the regular expression matching the file content, an empty one matches any.
+	Content string
+}
+
+// formFile represents a file parsed from a multipart form body.
+type formFile struct {
+	filename string
+	content  []byte
+}
+
+// parseForm parses the fields and files of the given URL-encoded or multipart form body.
+// It returns nil values if the content type is not a form.
+func parseForm(body []byte, contentType string) (url.Values, map[string][]formFile, error) {
+	kind, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	switch kind {
+	case "application/x-www-form-urlencoded":
+		fields, err := url.ParseQuery(string(body))
+		return fields, nil, err
+
+	case "multipart/form-data":
+		fields := url.Values{}
+		files := map[string][]formFile{}
+		reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return fields, files, nil
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			content, err := io.ReadAll(part)
+			if err != nil {
+				return nil, nil, err
+			}
+			if part.FileName() != "" {
+				files[part.FormName()] = append(files[part.FormName()], formFile{filename: part.FileName(), content: content})
+				continue
+			}
+			fields.Add(part.FormName(), string(content))
+		}
+	}
+	return nil, nil, nil
+}
+
+// matchFormField returns true if any of the given values matches the given regular expression.
+func matchFormField(values []string, value string) (bool, error) {
+	for _, v := range values {
+		if v == value {
+			return true, nil
+		}
+		match, err := regexp.MatchString(value, v)
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+// match returns true if any of the given files matches the expected file name and content.
+func (f *MultipartFile) match(files []formFile) (bool, error) {
+	for _, file := range files {
+		match, err := regexp.MatchString(f.Filename, file.filename)
+		if err != nil {
+			return false, err
+		}
+		if !match {
+			continue
+		}
+		if match, err = regexp.Match(f.Content, file.content); err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+// readForm reads and parses the form body of the given request, restoring the body.
+func readForm(req *http.Request, ereq *Request) (url.Values, map[string][]formFile, error) {
+	body, err := readBody(req, ereq.CompressionScheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseForm(body, req.Header.Get("Content-Type"))
+}
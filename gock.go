@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"regexp"
 	"sync"
+	"testing"
 )
 
 // mutex is used interally for locking thread-sensitive functions.
@@ -22,6 +23,10 @@ var config = struct {
 // ObserverFunc is implemented by users to inspect the outgoing intercepted HTTP traffic
 type ObserverFunc func(*http.Request, Mock)
 
+// ResponseObserverFunc is implemented by users to inspect the intercepted HTTP traffic
+// once the mock response, or error, has been produced. It must not consume the response body.
+type ResponseObserverFunc func(*http.Request, Mock, *http.Response, error)
+
 // DumpRequest is a default implementation of ObserverFunc that dumps
 // the HTTP/1.x wire representation of the http request
 var DumpRequest ObserverFunc = func(request *http.Request, mock Mock) {
@@ -30,10 +35,6 @@ var DumpRequest ObserverFunc = func(request *http.Request, mock Mock) {
 	fmt.Printf("\nMatches: %v\n---\n", mock != nil)
 }
 
-// TODO don't use global variable
-// track unmatched requests so they can be tested for
-var unmatchedRequests = []*http.Request{}
-
 // New creates and registers a new HTTP mock with
 // default settings and returns the Request DSL for HTTP mock
 // definition and set up.
@@ -102,20 +103,20 @@ func New(uri string) *Request {
 
 // Off disables the default HTTP interceptors and removes
 // all the registered mocks, even if they has not been intercepted yet.
-func (mocks _mocks) Off() {
+func (mocks *_mocks) Off() {
 	mocks.Flush()
 	// Disable()
 }
 
 // OffAll is like `Off()`, but it also removes the unmatched requests registry.
-func (mocks _mocks) OffAll() {
+func (mocks *_mocks) OffAll() {
 	mocks.Flush()
 	// Disable()
-	CleanUnmatchedRequest()
+	mocks.CleanUnmatchedRequests()
 }
 
-// Observe provides a hook to support inspection of the request and matched mock
-// TODO is used as a global variable
+// Observe provides a global hook to support inspection of the request and matched mock.
+// It's only used by transports without their own observers, see WithObserver.
 func Observe(fn ObserverFunc) {
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -151,29 +152,69 @@ func Observe(fn ObserverFunc) {
 // 	config.NetworkingFilters = []FilterRequestFunc{}
 // }
 
-// GetUnmatchedRequests returns all requests that have been received but haven't matched any mock
-func GetUnmatchedRequests() []*http.Request {
+// UnmatchedRequests returns all requests that have been received by the servers
+// of the given test but haven't matched any mock.
+func UnmatchedRequests(t *testing.T) []*http.Request {
+	t.Helper()
+
+	mocks, ok := _map.Load(t)
+	if !ok {
+		t.Errorf("TODO can't find mocks for this test")
+		return nil
+	}
+	return mocks.(*_mocks).UnmatchedRequests()
+}
+
+// HasUnmatchedRequest returns true if the servers of the given test have received
+// any requests that didn't match a mock.
+func HasUnmatchedRequest(t *testing.T) bool {
+	t.Helper()
+	return len(UnmatchedRequests(t)) > 0
+}
+
+// CleanUnmatchedRequest cleans the unmatched requests registry of the given test.
+func CleanUnmatchedRequest(t *testing.T) {
+	t.Helper()
+
+	mocks, ok := _map.Load(t)
+	if !ok {
+		t.Errorf("TODO can't find mocks for this test")
+		return
+	}
+	mocks.(*_mocks).CleanUnmatchedRequests()
+}
+
+// UnmatchedRequests returns all requests that have been received but haven't matched any mock.
+func (mocks *_mocks) UnmatchedRequests() []*http.Request {
 	mutex.Lock()
 	defer mutex.Unlock()
-	return unmatchedRequests
+	return append([]*http.Request{}, mocks.unmatched...)
 }
 
-// HasUnmatchedRequest returns true if gock has received any requests that didn't match a mock
-func HasUnmatchedRequest() bool {
-	return len(GetUnmatchedRequests()) > 0
+// CleanUnmatchedRequests cleans the unmatched requests registry.
+func (mocks *_mocks) CleanUnmatchedRequests() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	mocks.unmatched = nil
 }
 
-// CleanUnmatchedRequest cleans the unmatched requests internal registry.
-func CleanUnmatchedRequest() {
+// removeUnmatchedRequests removes the unmatched requests accepted by the given function.
+func (mocks *_mocks) removeUnmatchedRequests(remove func(*http.Request) bool) {
 	mutex.Lock()
 	defer mutex.Unlock()
-	unmatchedRequests = []*http.Request{}
+	unmatched := []*http.Request{}
+	for _, req := range mocks.unmatched {
+		if !remove(req) {
+			unmatched = append(unmatched, req)
+		}
+	}
+	mocks.unmatched = unmatched
 }
 
-func trackUnmatchedRequest(req *http.Request) {
+func (mocks *_mocks) trackUnmatchedRequest(req *http.Request) {
 	mutex.Lock()
 	defer mutex.Unlock()
-	unmatchedRequests = append(unmatchedRequests, req)
+	mocks.unmatched = append(mocks.unmatched, req)
 }
 
 func normalizeURI(uri string) string {
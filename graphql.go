@@ -0,0 +1,237 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// GraphQLRequest represents the GraphQL specific DSL of a mock Request,
+// used to match GraphQL operations sent to a single endpoint.
+type GraphQLRequest struct {
+	*Request
+}
+
+// GraphQLError represents an error of a GraphQL response.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// graphQLBody represents a GraphQL request, sent as JSON body or URL query params.
+type graphQLBody struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// GraphQL returns the GraphQL DSL of the request, e.g.
+//
+//	New(url).Post("/graphql").GraphQL().Operation("GetUser").Variables(map[string]any{"id": 1}).Reply(200)
+func (r *Request) GraphQL() *GraphQLRequest {
+	return &GraphQLRequest{Request: r}
+}
+
+// Operation defines the GraphQL operation name to match, either sent as operationName
+// or defined by the query document.
+func (r *GraphQLRequest) Operation(name string) *GraphQLRequest {
+	r.GraphQLOperation = name
+	return r
+}
+
+// Query defines the GraphQL query document to match.
+// Documents are compared normalized, so whitespace, commas and comments are ignored.
+func (r *GraphQLRequest) Query(query string) *GraphQLRequest {
+	r.GraphQLQuery = normalizeGraphQL(query)
+	return r
+}
+
+// Variables defines the GraphQL variables to match as a subset of the request variables.
+func (r *GraphQLRequest) Variables(variables map[string]interface{}) *GraphQLRequest {
+	r.GraphQLVariables = variables
+	return r
+}
+
+// GraphQL defines the response body as a GraphQL JSON envelope with the given data
+// and errors. A nil data is omitted when errors are given.
+func (r *Response) GraphQL(data interface{}, errors ...GraphQLError) *Response {
+	envelope := map[string]interface{}{}
+	if data != nil || len(errors) == 0 {
+		envelope["data"] = data
+	}
+	if len(errors) > 0 {
+		envelope["errors"] = errors
+	}
+	return r.JSON(envelope)
+}
+
+// matchGraphQL returns true if the given GraphQL request matches the expected operation,
+// query document and variables.
+func matchGraphQL(body *graphQLBody, ereq *Request) bool {
+	if ereq.GraphQLOperation != "" && graphQLOperationName(body) != ereq.GraphQLOperation {
+		return false
+	}
+	if ereq.GraphQLQuery != "" && normalizeGraphQL(body.Query) != ereq.GraphQLQuery {
+		return false
+	}
+	if ereq.GraphQLVariables != nil {
+		expected, err := normalizeJSON(ereq.GraphQLVariables)
+		if err != nil {
+			return false
+		}
+		actual, err := normalizeJSON(body.Variables)
+		if err != nil || !jsonMatch(actual, expected, true, ereq.ArrayOrderIgnored) {
+			return false
+		}
+	}
+	return true
+}
+
+// readGraphQL reads the GraphQL requests sent as JSON body, batched or not,
+// or as URL query params, restoring the request body.
+func readGraphQL(req *http.Request, ereq *Request) ([]*graphQLBody, error) {
+	if req.Method == http.MethodGet {
+		query := req.URL.Query()
+		body := &graphQLBody{Query: query.Get("query"), OperationName: query.Get("operationName")}
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &body.Variables); err != nil {
+				return nil, nil
+			}
+		}
+		return []*graphQLBody{body}, nil
+	}
+
+	data, err := readBody(req, ereq.CompressionScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodies []*graphQLBody
+	if err := json.Unmarshal(data, &bodies); err == nil {
+		return bodies, nil
+	}
+	body := &graphQLBody{}
+	if err := json.Unmarshal(data, body); err != nil {
+		return nil, nil
+	}
+	return []*graphQLBody{body}, nil
+}
+
+// graphQLOperationName returns the operation name of the given GraphQL request, falling back
+// to the name of the first operation defined by the query document, or "" if it's anonymous.
+func graphQLOperationName(body *graphQLBody) string {
+	if body.OperationName != "" {
+		return body.OperationName
+	}
+
+	query := normalizeGraphQL(body.Query)
+	depth := 0
+	fragment := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '"':
+			for i++; i < len(query) && query[i] != '"'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+
+		case c == '{':
+			// A selection set outside of any definition is an anonymous query shorthand
+			if depth == 0 && !fragment {
+				return ""
+			}
+			depth++
+
+		case c == '}':
+			if depth--; depth == 0 {
+				fragment = false
+			}
+
+		case depth == 0 && isGraphQLName(c):
+			start := i
+			for i < len(query) && isGraphQLName(query[i]) {
+				i++
+			}
+			switch name := query[start:i]; {
+			case name == "fragment":
+				fragment = true
+			case !fragment && (name == "query" || name == "mutation" || name == "subscription"):
+				return graphQLNameAt(query, i)
+			}
+			i--
+		}
+	}
+	return ""
+}
+
+// graphQLNameAt returns the operation name starting at the given position of the normalized query,
+// if it's followed by the operation variables, directives or selection set.
+func graphQLNameAt(query string, i int) string {
+	for i < len(query) && query[i] == ' ' {
+		i++
+	}
+	start := i
+	for i < len(query) && isGraphQLName(query[i]) {
+		i++
+	}
+	if start == i || i == len(query) || !strings.ContainsRune("({@", rune(query[i])) {
+		return ""
+	}
+	return query[start:i]
+}
+
+// normalizeGraphQL returns the given GraphQL document without comments and insignificant
+// whitespace and commas, so equivalent documents are equal.
+func normalizeGraphQL(query string) string {
+	buf := &strings.Builder{}
+	space := false
+	var last byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			space = true
+
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			space = true
+
+		case c == '"':
+			// Strings are kept as is, including escaped quotes
+			start := i
+			for i++; i < len(query) && query[i] != '"'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+			if space && isGraphQLName(last) {
+				buf.WriteByte(' ')
+			}
+			end := i + 1
+			if end > len(query) {
+				end = len(query)
+			}
+			buf.WriteString(query[start:end])
+			space, last = false, '"'
+
+		default:
+			// A space is only significant between two names, e.g. query GetUser
+			if space && isGraphQLName(c) && isGraphQLName(last) {
+				buf.WriteByte(' ')
+			}
+			buf.WriteByte(c)
+			space, last = false, c
+		}
+	}
+	return buf.String()
+}
+
+// isGraphQLName returns true if the given character can be part of a GraphQL name or number.
+func isGraphQLName(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
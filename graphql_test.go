@@ -0,0 +1,124 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeGraphQL(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		# Fetch a user
+		query GetUser($id: ID!, $full: Boolean = false) {
+			user(id: $id, note: "a,  b # c") {
+				id
+				name @include(if: $full)
+				... on Admin { role }
+			}
+		}`
+	require.Equal(t, `query GetUser($id:ID!$full:Boolean=false){user(id:$id note:"a,  b # c"){id name@include(if:$full)... on Admin{role}}}`, normalizeGraphQL(query))
+	require.Equal(t, normalizeGraphQL(query), normalizeGraphQL(`query GetUser($id:ID!,$full:Boolean=false){user(id:$id,note:"a,  b # c"){id,name @include(if:$full),... on Admin{role}}}`))
+}
+
+func TestGraphQLOperationName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "GetUser", graphQLOperationName(&graphQLBody{Query: "query GetUser { user { id } }"}))
+	require.Equal(t, "AddUser", graphQLOperationName(&graphQLBody{Query: "# query Foo\nmutation AddUser($name: String) { add(name: $name) }"}))
+	require.Equal(t, "Explicit", graphQLOperationName(&graphQLBody{Query: "query GetUser { id }", OperationName: "Explicit"}))
+	require.Equal(t, "", graphQLOperationName(&graphQLBody{Query: "{ user { id } }"}))
+	require.Equal(t, "", graphQLOperationName(&graphQLBody{Query: "query ($id: ID!) { user(id: $id) { id } }"}))
+	require.Equal(t, "", graphQLOperationName(&graphQLBody{Query: `{ search(query: "x") { id } }`}))
+	require.Equal(t, "", graphQLOperationName(&graphQLBody{Query: `{ search(text: "query Foo {") { id } }`}))
+	require.Equal(t, "GetUser", graphQLOperationName(&graphQLBody{Query: "fragment F on query { id }\nquery GetUser @cached { user { ...F } }"}))
+}
+
+func TestMatchGraphQL(t *testing.T) {
+	t.Parallel()
+
+	body := `{"query":"query GetUser($id: ID!) { user(id: $id) { id name } }","variables":{"id":"42","full":true}}`
+	cases := []struct {
+		ereq    *Request
+		matches bool
+	}{
+		{&Request{}, true},
+		{&Request{GraphQLOperation: "GetUser"}, true},
+		{&Request{GraphQLOperation: "GetPost"}, false},
+		{&Request{GraphQLQuery: normalizeGraphQL("query GetUser($id: ID!) {\n  user(id: $id) {\n    id\n    name\n  }\n}")}, true},
+		{&Request{GraphQLQuery: normalizeGraphQL("query GetUser($id: ID!) { user(id: $id) { id } }")}, false},
+		{&Request{GraphQLVariables: map[string]interface{}{"id": "42"}}, true},
+		{&Request{GraphQLVariables: map[string]interface{}{"id": "43"}}, false},
+		{&Request{GraphQLOperation: "GetUser", GraphQLVariables: map[string]interface{}{"full": true}}, true},
+	}
+
+	for i, test := range cases {
+		req := &http.Request{Method: "POST", Body: createReadCloser([]byte(body))}
+		matches, err := MatchGraphQL(req, test.ereq)
+		require.Equal(t, err, nil, i)
+		require.Equal(t, matches, test.matches, i)
+	}
+
+	// Batched and GET requests
+	req := &http.Request{Method: "POST", Body: createReadCloser([]byte(`[{"query":"query A { a }"},{"query":"query B { b }"}]`))}
+	matches, err := MatchGraphQL(req, &Request{GraphQLOperation: "B"})
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	u, _ := url.Parse("http://foo.com/graphql?" + url.Values{"query": {"query A { a }"}, "variables": {`{"id":1}`}}.Encode())
+	matches, err = MatchGraphQL(&http.Request{Method: "GET", URL: u}, &Request{GraphQLOperation: "A", GraphQLVariables: map[string]interface{}{"id": 1}})
+	require.NoError(t, err)
+	require.True(t, matches)
+}
+
+func TestResponseGraphQL(t *testing.T) {
+	t.Parallel()
+
+	res := NewResponse().GraphQL(map[string]interface{}{"user": map[string]interface{}{"id": "42"}})
+	require.JSONEq(t, `{"data":{"user":{"id":"42"}}}`, string(res.BodyBuffer))
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	res = NewResponse().GraphQL(nil, GraphQLError{Message: "not found", Path: []interface{}{"user"}})
+	require.JSONEq(t, `{"errors":[{"message":"not found","path":["user"]}]}`, string(res.BodyBuffer))
+}
+
+func TestMockGraphQL(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Post("/graphql").
+		GraphQL().
+		Operation("GetUser").
+		Variables(map[string]interface{}{"id": "1"}).
+		Reply(200).
+		GraphQL(map[string]interface{}{"user": map[string]interface{}{"name": "foo"}})
+	New(s.URL).
+		Post("/graphql").
+		GraphQL().
+		Operation("GetUser").
+		Variables(map[string]interface{}{"id": "2"}).
+		Reply(200).
+		GraphQL(nil, GraphQLError{Message: "user not found"})
+
+	send := func(id string) string {
+		data, _ := json.Marshal(map[string]interface{}{
+			"query":     "query GetUser($id: ID!) { user(id: $id) { name } }",
+			"variables": map[string]interface{}{"id": id},
+		})
+		res, err := http.Post(s.URL+"/graphql", "application/json", strings.NewReader(string(data)))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(res.Body)
+		return string(body)
+	}
+
+	require.JSONEq(t, `{"errors":[{"message":"user not found"}]}`, send("2"))
+	require.JSONEq(t, `{"data":{"user":{"name":"foo"}}}`, send("1"))
+	require.True(t, IsDone(t))
+}
@@ -0,0 +1,189 @@
+package httpmock
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Handler implements http.Handler replying the received requests with the registered mocks.
+// It's used internally by Server, and can be used to serve mocks outside of tests.
+type Handler struct {
+	// URL stores the base URL the mocks are registered for, see New.
+	URL string
+
+	// url stores the parsed base URL used to complete the received requests URL.
+	url *url.URL
+
+	// mocks stores the mocks served by the handler.
+	mocks *_mocks
+
+	// transport stores the transport used to match the mocks and build the responses.
+	transport *Transport
+
+	// errorf is used to report the errors found while writing the responses.
+	errorf func(format string, args ...interface{})
+
+	// mutex is used to make the admin API mock identifiers thread-safe.
+	mutex sync.Mutex
+
+	// ids stores the admin API identifiers of the mocks.
+	ids map[Mock]int
+
+	// nextID stores the last admin API identifier assigned to a mock.
+	nextID int
+
+	// admin enables serving the admin API under AdminPrefix.
+	admin bool
+}
+
+// NewHandler creates a new Handler serving the mocks registered via New(baseURL).
+func NewHandler(baseURL string) (*Handler, error) {
+	h := newHandler(&_mocks{}, log.Printf)
+	if err := h.setURL(normalizeURI(baseURL)); err != nil {
+		return nil, err
+	}
+	registerURL(h.mocks, h.URL)
+	return h, nil
+}
+
+func newHandler(mocks *_mocks, errorf func(format string, args ...interface{})) *Handler {
+	return &Handler{
+		mocks:     mocks,
+		transport: NewTransport(mocks),
+		errorf:    errorf,
+	}
+}
+
+// setURL defines the base URL of the handler.
+func (h *Handler) setURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	h.URL = uri
+	h.url = u
+	return nil
+}
+
+// EnableAdmin enables serving the admin API under AdminPrefix, see AdminPrefix.
+// It's disabled by default, so the prefix can be mocked as any other path.
+func (h *Handler) EnableAdmin() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.admin = true
+}
+
+// Transport returns the transport used to match the mocks.
+func (h *Handler) Transport() *Transport {
+	return h.transport
+}
+
+// Pending returns the pending mocks of the handler.
+func (h *Handler) Pending() []Mock {
+	return h.mocks.Pending()
+}
+
+// IsDone returns true if all the handler mocks have been triggered successfully.
+func (h *Handler) IsDone() bool {
+	return h.mocks.IsDone()
+}
+
+// UnmatchedRequests returns the requests received by the handler which haven't matched any mock.
+func (h *Handler) UnmatchedRequests() []*http.Request {
+	return h.mocks.UnmatchedRequests()
+}
+
+// ServeHTTP replies the given request with the matched mock response,
+// or with a 501 status code if the request cannot be matched.
+// Requests under AdminPrefix are served by the admin API, if enabled.
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if h.adminEnabled() && strings.HasPrefix(r.URL.Path, AdminPrefix) {
+		h.serveAdmin(rw, r)
+		return
+	}
+
+	r.URL.Scheme = h.url.Scheme
+	r.URL.Host = h.url.Host
+	rsp, err := h.transport.RoundTrip(r)
+	var fault *FaultError
+	if errors.As(err, &fault) {
+		abort(rw, fault.Fault)
+		return
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusNotImplemented)
+		rw.Write([]byte(err.Error()))
+		return
+	}
+	defer rsp.Body.Close()
+
+	header := rw.Header()
+	for k, vv := range rsp.Header {
+		for _, v := range vv {
+			header.Add(k, v)
+		}
+	}
+	if rsp.ContentLength > 0 && header.Get("Content-Length") == "" {
+		header.Set("Content-Length", strconv.FormatInt(rsp.ContentLength, 10))
+	}
+	if len(rsp.Trailer) > 0 {
+		declareTrailers(header, rsp.Trailer)
+	}
+
+	rw.WriteHeader(rsp.StatusCode)
+	if err := h.writeBody(rw, r, rsp); errors.As(err, &fault) {
+		if flusher, ok := rw.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		abort(rw, fault.Fault)
+	} else if err != nil {
+		h.errorf("httpmock: cannot write the mock response body: %v", err)
+	}
+
+	// Send the trailers, defined once the body has been read
+	for key, values := range rsp.Trailer {
+		header[key] = values
+	}
+}
+
+// writeBody writes the mock response body. Bodies of unknown length are streamed,
+// flushing every read part to the client.
+func (h *Handler) writeBody(rw http.ResponseWriter, r *http.Request, rsp *http.Response) error {
+	flusher, ok := rw.(http.Flusher)
+	if rsp.ContentLength >= 0 || !ok {
+		_, err := io.Copy(rw, rsp.Body)
+		return err
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := rsp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := rw.Write(buf[:n]); werr != nil {
+				return ignoreCanceled(r, werr)
+			}
+			flusher.Flush()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return ignoreCanceled(r, err)
+		}
+	}
+}
+
+// ignoreCanceled returns nil if the given request has been canceled by the client,
+// since the error is expected then, or the given error otherwise.
+func ignoreCanceled(r *http.Request, err error) error {
+	if r.Context().Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,36 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewUnstartedServer(nil)
+	handler, err := NewHandler("http://" + s.Listener.Addr().String())
+	require.NoError(t, err)
+	s.Config.Handler = handler
+	s.Start()
+	defer s.Close()
+
+	New(handler.URL).Get("/foo").Reply(200).BodyString("foo")
+	require.False(t, handler.IsDone())
+	require.Len(t, handler.Pending(), 1)
+
+	res, err := http.Get(s.URL + "/foo")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, "foo", string(body))
+	require.True(t, handler.IsDone())
+
+	res, err = http.Get(s.URL + "/bar")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+	require.Len(t, handler.UnmatchedRequests(), 1)
+}
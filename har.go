@@ -0,0 +1,306 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+// HAR represents an HTTP Archive (HAR) 1.2 document.
+// See http://www.softwareishard.com/blog/har-12-spec/
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog represents the root log object of a HAR document.
+type HARLog struct {
+	Version string      `json:"version"`
+	Creator HARCreator  `json:"creator"`
+	Entries []*HAREntry `json:"entries"`
+}
+
+// HARCreator represents the application which created the HAR document.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry represents an HTTP exchange of a HAR document.
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARRequest represents the request of a HAR entry.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARCookie    `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse represents the response of a HAR entry.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARCookie    `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARCookie represents a cookie of a HAR request or response.
+type HARCookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARNameValue represents a header field or query param of a HAR request or response.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData represents the request body of a HAR entry.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent represents the response body of a HAR entry.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings represents the timings of a HAR entry.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// LoadHAR registers a new mock for every entry of the given HAR file for the given server.
+// The test fails if the HAR file cannot be read.
+func LoadHAR(t *testing.T, server *httptest.Server, path string) []*Request {
+	t.Helper()
+
+	har, err := ReadHAR(path)
+	if err != nil {
+		t.Fatalf("httpmock: cannot load HAR from %s: %v", path, err)
+		return nil
+	}
+
+	reqs := []*Request{}
+	for _, entry := range har.Log.Entries {
+		fixture, err := entry.Fixture()
+		if err != nil {
+			t.Fatalf("httpmock: invalid HAR entry %s %s: %v", entry.Request.Method, entry.Request.URL, err)
+			return nil
+		}
+		reqs = append(reqs, fixture.Mock(server.URL))
+	}
+	return reqs
+}
+
+// ExportHAR writes the request history of the given test into the given HAR file.
+// The test fails if the HAR file cannot be written.
+func ExportHAR(t *testing.T, path string) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(NewHAR(History(t)), "", "  ")
+	if err != nil {
+		t.Fatalf("httpmock: cannot encode HAR: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, append(data, EOL), 0o644); err != nil {
+		t.Fatalf("httpmock: cannot write HAR to %s: %v", path, err)
+	}
+}
+
+// ReadHAR reads the HAR document stored in the given file path.
+func ReadHAR(path string) (*HAR, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	har := &HAR{}
+	if err := json.Unmarshal(data, har); err != nil {
+		return nil, err
+	}
+	return har, nil
+}
+
+// NewHAR creates a new HAR document based on the given matched requests
+// and the responses replied to them.
+func NewHAR(calls []*Call) *HAR {
+	har := &HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "httpmock", Version: Version},
+		Entries: []*HAREntry{},
+	}}
+
+	// Response bodies are captured while the clients read them
+	historyMutex.RLock()
+	defer historyMutex.RUnlock()
+	for _, call := range calls {
+		har.Log.Entries = append(har.Log.Entries, newHAREntry(call))
+	}
+	return har
+}
+
+// Fixture converts the HAR entry into a Fixture which can be replayed as a mock.
+func (e *HAREntry) Fixture() (*Fixture, error) {
+	u, err := url.Parse(e.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{
+		Request: FixtureRequest{
+			Method: e.Request.Method,
+			Path:   u.Path,
+			Query:  u.RawQuery,
+			Header: harHeader(e.Request.Headers),
+		},
+		Response: FixtureResponse{
+			Status:       e.Response.Status,
+			Header:       harHeader(e.Response.Headers),
+			Body:         e.Response.Content.Text,
+			BodyEncoding: e.Response.Content.Encoding,
+		},
+	}
+	if e.Request.PostData != nil {
+		fixture.Request.Body = e.Request.PostData.Text
+		if fixture.Request.Header.Get("Content-Type") == "" && e.Request.PostData.MimeType != "" {
+			fixture.Request.Header.Set("Content-Type", e.Request.PostData.MimeType)
+		}
+	}
+
+	// HAR content is stored decoded, so the encoding header doesn't apply anymore
+	removeHopHeaders(fixture.Response.Header)
+	fixture.Response.Header.Del("Content-Encoding")
+
+	return fixture, nil
+}
+
+// newHAREntry creates a new HAR entry based on the given matched request.
+func newHAREntry(call *Call) *HAREntry {
+	entry := &HAREntry{
+		StartedDateTime: call.Time.Format(time.RFC3339Nano),
+		Request: HARRequest{
+			Method:      call.Method,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []HARCookie{},
+			Headers:     harNameValues(call.Header),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(call.Body),
+		},
+		Response: HARResponse{
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []HARCookie{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+	}
+
+	if call.URL != nil {
+		entry.Request.URL = call.URL.String()
+		entry.Request.QueryString = harNameValues(call.URL.Query())
+	}
+	if len(call.Body) > 0 {
+		entry.Request.PostData = &HARPostData{MimeType: call.Header.Get("Content-Type"), Text: string(call.Body)}
+	}
+	for _, cookie := range (&http.Request{Header: call.Header}).Cookies() {
+		entry.Request.Cookies = append(entry.Request.Cookies, HARCookie{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	// Requests replied with an error have no response, reported with a 0 status
+	res := call.Response
+	if res == nil {
+		return entry
+	}
+
+	body := decodeContent(res.Body, res.Header.Get("Content-Encoding"))
+	entry.Response.Status = res.StatusCode
+	entry.Response.StatusText = http.StatusText(res.StatusCode)
+	entry.Response.Headers = harNameValues(res.Header)
+	entry.Response.BodySize = len(res.Body)
+	entry.Response.Content.Size = len(body)
+	entry.Response.Content.MimeType = res.Header.Get("Content-Type")
+	entry.Response.Content.Text, entry.Response.Content.Encoding = encodeBody(body)
+	for _, cookie := range (&http.Response{Header: res.Header}).Cookies() {
+		entry.Response.Cookies = append(entry.Response.Cookies, HARCookie{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	return entry
+}
+
+// decodeContent decompresses the given response body encoded with the given scheme, since HAR
+// content is stored decoded. The body is returned as is if it cannot be decompressed.
+func decodeContent(body []byte, scheme string) []byte {
+	if scheme == "" || codecFor(scheme) == nil {
+		return body
+	}
+	reader, err := compressionReader(createReadCloser(body), scheme)
+	if err != nil {
+		return body
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return body
+	}
+	return decoded
+}
+
+func harNameValues(values map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := []HARNameValue{}
+	for _, name := range names {
+		for _, v := range values[name] {
+			res = append(res, HARNameValue{Name: name, Value: v})
+		}
+	}
+	return res
+}
+
+func harHeader(values []HARNameValue) http.Header {
+	header := http.Header{}
+	for _, nv := range values {
+		// HTTP/2 pseudo headers, e.g. :authority, are not header fields
+		if len(nv.Name) > 0 && nv.Name[0] == ':' {
+			continue
+		}
+		header.Add(nv.Name, nv.Value)
+	}
+	return header
+}
@@ -0,0 +1,157 @@
+package httpmock
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadHAR(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	reqs := LoadHAR(t, s, "testdata/har/api.har")
+	require.Len(t, reqs, 2)
+
+	res, err := http.Get(s.URL + "/users?page=2")
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	require.Empty(t, res.Header.Get("Content-Encoding"))
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, `[{"id":3},{"id":4}]`, string(body))
+
+	res, err = http.Post(s.URL+"/users", "application/json", strings.NewReader(`{"name":"bar"}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+
+	res, err = http.Post(s.URL+"/users", "application/json", strings.NewReader(`{"name":"foo"}`))
+	require.NoError(t, err)
+	require.Equal(t, 201, res.StatusCode)
+	require.Equal(t, "/users/5", res.Header.Get("Location"))
+	require.True(t, IsDone(t))
+}
+
+func TestLoadHARBinaryBody(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "binary.har")
+	data := `{"log": {"version": "1.2", "entries": [{
+		"request": {"method": "POST", "url": "https://api.example.com/upload", "headers": [],
+			"postData": {"mimeType": "application/octet-stream", "text": "raw data"}},
+		"response": {"status": 201, "headers": [], "content": {"size": 0, "mimeType": ""}}
+	}]}}`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	har, err := ReadHAR(path)
+	require.NoError(t, err)
+	fixture, err := har.Log.Entries[0].Fixture()
+	require.NoError(t, err)
+	require.Equal(t, "application/octet-stream", fixture.Request.Header.Get("Content-Type"))
+
+	s := Server(t)
+	LoadHAR(t, s, path)
+
+	res, err := http.Post(s.URL+"/upload", "application/octet-stream", strings.NewReader("other data"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+
+	res, err = http.Post(s.URL+"/upload", "application/octet-stream", strings.NewReader("raw data"))
+	require.NoError(t, err)
+	require.Equal(t, 201, res.StatusCode)
+	require.True(t, IsDone(t))
+}
+
+func TestExportHAR(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Post("/users").
+		Reply(201).
+		SetCookie(&http.Cookie{Name: "session", Value: "abc"}).
+		JSON(map[string]int{"id": 1})
+
+	req, _ := http.NewRequest("POST", s.URL+"/users?notify=1", strings.NewReader(`{"name":"foo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "history.har")
+	ExportHAR(t, path)
+
+	har, err := ReadHAR(path)
+	require.NoError(t, err)
+	require.Equal(t, "1.2", har.Log.Version)
+	require.Len(t, har.Log.Entries, 1)
+
+	entry := har.Log.Entries[0]
+	require.Equal(t, "POST", entry.Request.Method)
+	require.Equal(t, s.URL+"/users?notify=1", entry.Request.URL)
+	require.Equal(t, []HARNameValue{{Name: "notify", Value: "1"}}, entry.Request.QueryString)
+	require.Equal(t, `{"name":"foo"}`, entry.Request.PostData.Text)
+	require.Equal(t, 201, entry.Response.Status)
+	require.Equal(t, "Created", entry.Response.StatusText)
+	require.Equal(t, []HARCookie{{Name: "session", Value: "abc"}}, entry.Response.Cookies)
+	require.Equal(t, "application/json", entry.Response.Content.MimeType)
+	require.JSONEq(t, `{"id":1}`, entry.Response.Content.Text)
+
+	// The exported HAR can be loaded back as mocks
+	s2 := Server(t)
+	LoadHAR(t, s2, path)
+	res, err := http.Post(s2.URL+"/users?notify=1", "application/json", strings.NewReader(`{"name":"foo"}`))
+	require.NoError(t, err)
+	require.Equal(t, 201, res.StatusCode)
+	require.Len(t, res.Cookies(), 1)
+}
+
+func TestExportHARProducedResponse(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Get("/echo").
+		ReplyHandler(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 202,
+				Header:     http.Header{"Content-Type": []string{"text/plain"}},
+				Body:       io.NopCloser(strings.NewReader(req.URL.Query().Get("name"))),
+			}, nil
+		}).
+		Map(func(res *http.Response) *http.Response {
+			res.Header.Set("X-Mapped", "1")
+			return res
+		})
+	New(s.URL).Get("/gzip").Reply(200).Compression("gzip").BodyString("compressed")
+	New(s.URL).Get("/error").ReplyError(errors.New("boom"))
+
+	for _, path := range []string{"/echo?name=foo", "/gzip", "/error"} {
+		res, err := http.Get(s.URL + path)
+		require.NoError(t, err)
+		io.ReadAll(res.Body)
+		res.Body.Close()
+	}
+
+	har := NewHAR(History(t))
+	require.Len(t, har.Log.Entries, 3)
+
+	echo := har.Log.Entries[0].Response
+	require.Equal(t, 202, echo.Status)
+	require.Equal(t, "text/plain", echo.Content.MimeType)
+	require.Contains(t, echo.Headers, HARNameValue{Name: "X-Mapped", Value: "1"})
+	require.Equal(t, "foo", echo.Content.Text)
+
+	// HAR content is stored decoded
+	compressed := har.Log.Entries[1].Response
+	require.Contains(t, compressed.Headers, HARNameValue{Name: "Content-Encoding", Value: "gzip"})
+	require.Equal(t, "compressed", compressed.Content.Text)
+	require.Equal(t, len("compressed"), compressed.Content.Size)
+
+	require.Equal(t, 0, har.Log.Entries[2].Response.Status)
+}
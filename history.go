@@ -0,0 +1,145 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// historyMutex is used interally for request history synchronization.
+var historyMutex = sync.RWMutex{}
+
+// Call represents an intercepted HTTP request which has been matched by a mock.
+type Call struct {
+	// Method stores the request HTTP method.
+	Method string
+
+	// URL stores a copy of the request URL.
+	URL *url.URL
+
+	// Header stores a copy of the request header fields.
+	Header http.Header
+
+	// Body stores a snapshot of the request body.
+	Body []byte
+
+	// Time stores when the request has been matched.
+	Time time.Time
+
+	// Mock stores the mock which served the request.
+	Mock Mock
+
+	// Response stores the response replied to the request, or nil if the mock replied with an error.
+	Response *CallResponse
+}
+
+// CallResponse represents the response replied to a matched request, as produced by the mock
+// after its reply handler, filters and mappers run.
+type CallResponse struct {
+	// StatusCode stores the response status code.
+	StatusCode int
+
+	// Header stores a copy of the response header fields.
+	Header http.Header
+
+	// Body stores the response body read by the client so far.
+	Body []byte
+}
+
+// CallRecorder is an optional interface implemented by the mocks recording the requests
+// they match, such as Mocker.
+type CallRecorder interface {
+	// Calls returns the requests matched by the current mock.
+	Calls() []*Call
+}
+
+// Calls returns the requests matched by the given mock, in arrival order,
+// or nil if the mock doesn't implement CallRecorder.
+func Calls(mock Mock) []*Call {
+	if recorder, ok := mock.(CallRecorder); ok {
+		return recorder.Calls()
+	}
+	return nil
+}
+
+// newCall creates a new Call snapshot of the given request, restoring the request body.
+func newCall(req *http.Request, mock Mock) *Call {
+	call := &Call{
+		Method: req.Method,
+		Header: req.Header.Clone(),
+		Time:   time.Now(),
+		Mock:   mock,
+	}
+
+	if req.URL != nil {
+		u := *req.URL
+		call.URL = &u
+	}
+
+	if req.Body != nil {
+		if body, err := io.ReadAll(req.Body); err == nil {
+			call.Body = body
+			req.Body = createReadCloser(body)
+		}
+	}
+
+	return call
+}
+
+// recordResponse records the given response in the call, capturing its body as it's read.
+func (c *Call) recordResponse(res *http.Response) {
+	response := &CallResponse{StatusCode: res.StatusCode, Header: res.Header.Clone()}
+
+	historyMutex.Lock()
+	c.Response = response
+	historyMutex.Unlock()
+
+	if res.Body != nil {
+		res.Body = &responseRecorder{ReadCloser: res.Body, response: response}
+	}
+}
+
+// responseRecorder captures the response body read through it into a CallResponse.
+type responseRecorder struct {
+	io.ReadCloser
+	response *CallResponse
+}
+
+func (r *responseRecorder) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		historyMutex.Lock()
+		r.response.Body = append(r.response.Body, p[:n]...)
+		historyMutex.Unlock()
+	}
+	return n, err
+}
+
+// History returns every request matched by the mocks of the given test, in arrival order.
+func History(t *testing.T) []*Call {
+	t.Helper()
+
+	mocks, ok := _map.Load(t)
+	if !ok {
+		t.Errorf("TODO can't find mocks for this test")
+		return nil
+	}
+	return mocks.(*_mocks).History()
+}
+
+// History returns every request matched by the registered mocks, in arrival order.
+func (mocks *_mocks) History() []*Call {
+	historyMutex.RLock()
+	defer historyMutex.RUnlock()
+	return append([]*Call{}, mocks.history...)
+}
+
+// trackCall adds the given call into the mocks request history.
+func (mocks *_mocks) trackCall(call *Call) {
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+	mocks.history = append(mocks.history, call)
+}
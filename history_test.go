@@ -0,0 +1,64 @@
+package httpmock
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	foo := New(s.URL).Post("/foo").Reply(201)
+	bar := New(s.URL).Get("/bar").Persist().Reply(200)
+
+	req, err := http.NewRequest("POST", s.URL+"/foo?id=1", bytes.NewBufferString("foo bar"))
+	require.NoError(t, err)
+	req.Header.Set("X-Trace", "abc")
+	_, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = http.Get(s.URL + "/bar")
+		require.NoError(t, err)
+	}
+
+	history := History(t)
+	require.Len(t, history, 3)
+	require.Equal(t, "POST", history[0].Method)
+	require.Equal(t, "/foo", history[0].URL.Path)
+	require.Equal(t, "id=1", history[0].URL.RawQuery)
+	require.Equal(t, "abc", history[0].Header.Get("X-Trace"))
+	require.Equal(t, []byte("foo bar"), history[0].Body)
+	require.Equal(t, foo.Mock, history[0].Mock)
+	require.False(t, history[0].Time.IsZero())
+
+	require.Len(t, Calls(foo.Mock), 1)
+	require.Len(t, Calls(bar.Mock), 2)
+	require.Equal(t, "/bar", Calls(bar.Mock)[1].URL.Path)
+}
+
+func TestHistoryUnmatched(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	mock := New(s.URL).Get("/foo").Reply(200).Mock
+
+	_, err := http.Get(s.URL + "/bar")
+	require.NoError(t, err)
+
+	require.Len(t, History(t), 0)
+	require.Len(t, Calls(mock), 0)
+}
+
+func TestCallsCustomMock(t *testing.T) {
+	t.Parallel()
+
+	// Mocks which don't record their calls are still supported
+	mock := struct{ Mock }{NewMock(NewRequest(), NewResponse())}
+	require.Nil(t, Calls(mock))
+	require.NotNil(t, Calls(mock.Mock))
+}
@@ -0,0 +1,212 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsonPathStep represents a single step of a JSONPath expression:
+// an object key, an array index, or a wildcard.
+type jsonPathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONPath parses the supported JSONPath subset: the $ root, .key and ['key'] children,
+// [n] array indexes, negative ones counting from the end, and .* or [*] wildcards.
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("gock: invalid JSON path %q: must start with $", path)
+	}
+
+	steps := []jsonPathStep{}
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("gock: invalid JSON path %q: recursive descent is not supported", path)
+
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("gock: invalid JSON path %q: empty key", path)
+			}
+			steps = append(steps, jsonPathStep{key: key, wildcard: key == "*"})
+			rest = rest[end+1:]
+
+		case rest[0] == '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("gock: invalid JSON path %q: missing ]", path)
+			}
+			selector := rest[1:end]
+			rest = rest[end+1:]
+
+			if selector == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+				continue
+			}
+			if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+				steps = append(steps, jsonPathStep{key: selector[1 : len(selector)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(selector)
+			if err != nil {
+				return nil, fmt.Errorf("gock: invalid JSON path %q: invalid index %q", path, selector)
+			}
+			steps = append(steps, jsonPathStep{index: index, isIndex: true})
+
+		default:
+			return nil, fmt.Errorf("gock: invalid JSON path %q: unexpected %q", path, rest[:1])
+		}
+	}
+	return steps, nil
+}
+
+// jsonPathValues returns the values of the given decoded JSON document selected by the given JSONPath steps.
+func jsonPathValues(doc interface{}, steps []jsonPathStep) []interface{} {
+	values := []interface{}{doc}
+	for _, step := range steps {
+		next := []interface{}{}
+		for _, value := range values {
+			switch value := value.(type) {
+			case map[string]interface{}:
+				if step.wildcard {
+					for _, v := range value {
+						next = append(next, v)
+					}
+				} else if v, ok := value[step.key]; ok && !step.isIndex {
+					next = append(next, v)
+				}
+			case []interface{}:
+				if step.wildcard {
+					next = append(next, value...)
+				} else if step.isIndex {
+					index := step.index
+					if index < 0 {
+						index += len(value)
+					}
+					if index >= 0 && index < len(value) {
+						next = append(next, value[index])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// matchJSONPath returns true if any value selected by the given JSONPath in the given decoded
+// JSON document matches the expected value, or the expected regular expression.
+func matchJSONPath(doc interface{}, path string, expected interface{}, partial, ignoreOrder bool) bool {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return false
+	}
+
+	if re, ok := expected.(*regexp.Regexp); ok {
+		for _, value := range jsonPathValues(doc, steps) {
+			if re.MatchString(jsonString(value)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	expected, err = normalizeJSON(expected)
+	if err != nil {
+		return false
+	}
+	for _, value := range jsonPathValues(doc, steps) {
+		if jsonMatch(value, expected, partial, ignoreOrder) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonMatch returns true if the given decoded JSON values are equal, or if the actual value
+// contains the expected one in partial mode: every expected object key must be present
+// and match, while extra keys are ignored. Arrays must have the same length, and their items
+// may be in any order when ignoreOrder is enabled.
+func jsonMatch(actual, expected interface{}, partial, ignoreOrder bool) bool {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		obj, ok := actual.(map[string]interface{})
+		if !ok || (!partial && len(obj) != len(expected)) {
+			return false
+		}
+		for key, value := range expected {
+			v, ok := obj[key]
+			if !ok || !jsonMatch(v, value, partial, ignoreOrder) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		arr, ok := actual.([]interface{})
+		if !ok || len(arr) != len(expected) {
+			return false
+		}
+		if !ignoreOrder {
+			for i := range expected {
+				if !jsonMatch(arr[i], expected[i], partial, ignoreOrder) {
+					return false
+				}
+			}
+			return true
+		}
+
+		used := make([]bool, len(arr))
+		for _, value := range expected {
+			found := false
+			for i, v := range arr {
+				if !used[i] && jsonMatch(v, value, partial, ignoreOrder) {
+					used[i], found = true, true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return reflect.DeepEqual(actual, expected)
+	}
+}
+
+// normalizeJSON converts the given value into its decoded JSON representation,
+// e.g. ints into float64 and structs into maps.
+func normalizeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	err = json.Unmarshal(data, &res)
+	return res, err
+}
+
+// jsonString returns the string representation of the given decoded JSON value,
+// strings as is and JSON encoded otherwise.
+func jsonString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,43 @@
+package httpmock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	t.Parallel()
+
+	steps, err := parseJSONPath(`$.user['first name'].tags[0][*].*`)
+	require.NoError(t, err)
+	require.Equal(t, []jsonPathStep{
+		{key: "user"},
+		{key: "first name"},
+		{key: "tags"},
+		{index: 0, isIndex: true},
+		{wildcard: true},
+		{key: "*", wildcard: true},
+	}, steps)
+
+	steps, err = parseJSONPath("$")
+	require.NoError(t, err)
+	require.Empty(t, steps)
+
+	for _, path := range []string{"user", "$..id", "$.", "$[0", "$[foo]", "$user"} {
+		_, err := parseJSONPath(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestJSONMatch(t *testing.T) {
+	t.Parallel()
+
+	actual := map[string]interface{}{"id": 1.0, "tags": []interface{}{"a", "b"}}
+	require.True(t, jsonMatch(actual, map[string]interface{}{"id": 1.0, "tags": []interface{}{"a", "b"}}, false, false))
+	require.False(t, jsonMatch(actual, map[string]interface{}{"id": 1.0}, false, false))
+	require.True(t, jsonMatch(actual, map[string]interface{}{"id": 1.0}, true, false))
+	require.False(t, jsonMatch(actual, map[string]interface{}{"tags": []interface{}{"b", "a"}}, true, false))
+	require.True(t, jsonMatch(actual, map[string]interface{}{"tags": []interface{}{"b", "a"}}, true, true))
+	require.False(t, jsonMatch(actual, []interface{}{"a"}, true, true))
+}
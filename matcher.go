@@ -9,6 +9,7 @@ var MatchersHeader = []MatchFunc{
 	MatchHost,
 	MatchPath,
 	MatchHeaders,
+	MatchCookies,
 	MatchQueryParams,
 	MatchPathParams,
 }
@@ -16,6 +17,9 @@ var MatchersHeader = []MatchFunc{
 // MatchersBody exposes an slice of HTTP body specific built-in mock matchers.
 var MatchersBody = []MatchFunc{
 	MatchBody,
+	MatchJSONPaths,
+	MatchForm,
+	MatchGraphQL,
 }
 
 // Matchers stores all the built-in mock matchers.
@@ -109,30 +113,46 @@ func (m *MockMatcher) Clone() *MockMatcher {
 // Match matches the given http.Request with a mock request
 // returning true in case that the request matches, otherwise false.
 func (m *MockMatcher) Match(req *http.Request, ereq *Request) (bool, error) {
-	for _, matcher := range m.Matchers {
-		matches, err := matcher(req, ereq)
-		if err != nil {
-			return false, err
-		}
-		if !matches {
-			return false, nil
-		}
+	result, err := m.MatchResult(req, ereq)
+	if err != nil {
+		return false, err
 	}
-	return true, nil
+	return result.Matches(), nil
 }
 
 // MatchMock is a helper function that matches the given http.Request
 // in the list of registered mocks, returning it if matches or error if it fails.
 func (mocks *_mocks) MatchMock(req *http.Request) (Mock, error) {
-	// for _, mock := range mocks.GetAll() {
+	mock, _, err := mocks.matchMock(req)
+	return mock, err
+}
+
+// matchMock matches the given http.Request in the list of registered mocks, returning
+// the matched mock, if any, and the match results of the pending mocks which rejected it.
+func (mocks *_mocks) matchMock(req *http.Request) (Mock, []*MatchResult, error) {
+	results := []*MatchResult{}
 	for _, mock := range mocks.mocks {
-		matches, err := mock.Match(req)
+		var result *MatchResult
+		var matches bool
+		var err error
+		if m, ok := mock.(*Mocker); ok {
+			result, err = m.matchResult(req)
+			matches = result != nil && result.Matches()
+		} else {
+			matches, err = mock.Match(req)
+		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if matches {
-			return mock, nil
+			if calls := Calls(mock); len(calls) > 0 {
+				mocks.trackCall(calls[len(calls)-1])
+			}
+			return mock, nil, nil
+		}
+		if result != nil {
+			results = append(results, result)
 		}
 	}
-	return nil, nil
+	return nil, results, nil
 }
@@ -11,8 +11,8 @@ import (
 func TestRegisteredMatchers(t *testing.T) {
 	t.Parallel()
 
-	require.Equal(t, len(MatchersHeader), 7)
-	require.Equal(t, len(MatchersBody), 1)
+	require.Equal(t, len(MatchersHeader), 8)
+	require.Equal(t, len(MatchersBody), 4)
 }
 
 func TestNewMatcher(t *testing.T) {
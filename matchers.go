@@ -1,11 +1,9 @@
 package httpmock
 
 import (
-	"compress/gzip"
 	"encoding/json"
 	"io"
 	"net/http"
-	"reflect"
 	"regexp"
 	"strings"
 
@@ -36,7 +34,8 @@ var BodyTypeAliases = map[string]string{
 	"url":  "application/x-www-form-urlencoded",
 }
 
-// CompressionSchemes stores the supported Content-Encoding types for decompression.
+// CompressionSchemes stores the supported Content-Encoding types for decompression,
+// besides the ones of the codecs registered via RegisterCodec.
 var CompressionSchemes = []string{
 	"gzip",
 }
@@ -102,6 +101,25 @@ func MatchHeaders(req *http.Request, ereq *Request) (bool, error) {
 	return true, nil
 }
 
+// MatchCookies matches the cookies of the given request.
+func MatchCookies(req *http.Request, ereq *Request) (bool, error) {
+	for _, ecookie := range ereq.Cookies {
+		cookie, err := req.Cookie(ecookie.Name)
+		if err != nil {
+			return false, nil
+		}
+
+		if ecookie.Value == cookie.Value {
+			continue
+		}
+		// Values which are not valid regular expressions only match literally
+		if match, err := regexp.MatchString(ecookie.Value, cookie.Value); err != nil || !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // MatchQueryParams matches the URL query params fields of the given request.
 func MatchQueryParams(req *http.Request, ereq *Request) (bool, error) {
 	for key, value := range ereq.URLStruct.Query() {
@@ -159,28 +177,17 @@ func MatchBody(req *http.Request, ereq *Request) (bool, error) {
 		return false, nil
 	}
 
-	// Create a reader for the body depending on compression type
-	bodyReader := req.Body
-	if ereq.CompressionScheme != "" {
-		if ereq.CompressionScheme != req.Header.Get("Content-Encoding") {
-			return false, nil
-		}
-		compressedBodyReader, err := compressionReader(req.Body, ereq.CompressionScheme)
-		if err != nil {
-			return false, err
-		}
-		bodyReader = compressedBodyReader
+	// Can only match the expected compression scheme
+	if ereq.CompressionScheme != "" && ereq.CompressionScheme != req.Header.Get("Content-Encoding") {
+		return false, nil
 	}
 
-	// Read the whole request body
-	body, err := io.ReadAll(bodyReader)
+	// Read the whole request body, decompressing it if needed, the raw body is restored for the next matchers
+	body, err := readBody(req, ereq.CompressionScheme)
 	if err != nil {
 		return false, err
 	}
 
-	// Restore body reader stream
-	req.Body = createReadCloser(body)
-
 	// If empty, ignore the match
 	if len(body) == 0 && len(ereq.BodyBuffer) != 0 {
 		return false, nil
@@ -202,17 +209,85 @@ func MatchBody(req *http.Request, ereq *Request) (bool, error) {
 	// todo - add conditional do only perform the conversion of body bytes
 	// representation of JSON to a map and then compare them for equality.
 
-	// Check if the key + value pairs match
-	var bodyMap map[string]interface{}
-	var matchMap map[string]interface{}
+	// Check if the JSON values match, optionally as a subset and ignoring the arrays order
+	var bodyValue interface{}
+	var matchValue interface{}
+
+	// Ensure that both byte bodies that that should be JSON can be decoded.
+	umErr := json.Unmarshal(body, &bodyValue)
+	umErr2 := json.Unmarshal(ereq.BodyBuffer, &matchValue)
+	if umErr == nil && umErr2 == nil && jsonMatch(bodyValue, matchValue, ereq.JSONPartial, ereq.ArrayOrderIgnored) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// MatchJSONPaths matches the values selected by the JSONPath expressions in the JSON request body.
+func MatchJSONPaths(req *http.Request, ereq *Request) (bool, error) {
+	if len(ereq.JSONPaths) == 0 {
+		return true, nil
+	}
+
+	body, err := readBody(req, ereq.CompressionScheme)
+	if err != nil {
+		return false, err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return false, nil
+	}
+	for path, value := range ereq.JSONPaths {
+		if !matchJSONPath(doc, path, value, ereq.JSONPartial, ereq.ArrayOrderIgnored) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MatchForm matches the fields and files of the URL-encoded or multipart form request body.
+func MatchForm(req *http.Request, ereq *Request) (bool, error) {
+	if len(ereq.FormFields) == 0 && len(ereq.MultipartFiles) == 0 {
+		return true, nil
+	}
+
+	fields, files, err := readForm(req, ereq)
+	if err != nil || fields == nil {
+		return false, nil
+	}
 
-	// Ensure that both byte bodies that that should be JSON can be converted to maps.
-	umErr := json.Unmarshal(body, &bodyMap)
-	umErr2 := json.Unmarshal(ereq.BodyBuffer, &matchMap)
-	if umErr == nil && umErr2 == nil && reflect.DeepEqual(bodyMap, matchMap) {
+	for key, value := range ereq.FormFields {
+		match, err := matchFormField(fields[key], value)
+		if err != nil || !match {
+			return false, err
+		}
+	}
+	for _, file := range ereq.MultipartFiles {
+		match, err := file.match(files[file.Field])
+		if err != nil || !match {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// MatchGraphQL matches the GraphQL operation name, query document and variables of the request.
+// Batched requests match if any of their operations matches.
+func MatchGraphQL(req *http.Request, ereq *Request) (bool, error) {
+	if ereq.GraphQLOperation == "" && ereq.GraphQLQuery == "" && ereq.GraphQLVariables == nil {
 		return true, nil
 	}
 
+	bodies, err := readGraphQL(req, ereq)
+	if err != nil {
+		return false, err
+	}
+	for _, body := range bodies {
+		if matchGraphQL(body, ereq) {
+			return true, nil
+		}
+	}
 	return false, nil
 }
 
@@ -237,7 +312,7 @@ func supportedType(req *http.Request, ereq *Request) bool {
 
 func supportedCompressionScheme(req *http.Request) bool {
 	encoding := req.Header.Get("Content-Encoding")
-	if encoding == "" {
+	if encoding == "" || codecFor(encoding) != nil {
 		return true
 	}
 
@@ -258,11 +333,33 @@ func castToString(buf []byte) string {
 	return str
 }
 
+// readBody reads the whole request body, restoring it for the next matchers,
+// and decompresses it if it's encoded with the given compression scheme.
+func readBody(req *http.Request, scheme string) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = createReadCloser(body)
+
+	if scheme == "" || scheme != req.Header.Get("Content-Encoding") {
+		return body, nil
+	}
+	reader, err := compressionReader(createReadCloser(body), scheme)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
 func compressionReader(r io.ReadCloser, scheme string) (io.ReadCloser, error) {
-	switch scheme {
-	case "gzip":
-		return gzip.NewReader(r)
-	default:
+	codec := codecFor(scheme)
+	if codec == nil {
 		return r, nil
 	}
+	return codec.NewReader(r)
 }
@@ -1,8 +1,12 @@
 package httpmock
 
 import (
+	"bytes"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -152,6 +156,40 @@ func TestMatchHeaders(t *testing.T) {
 	}
 }
 
+func TestMatchCookies(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		values  map[string]string
+		cookies []*http.Cookie
+		matches bool
+	}{
+		{map[string]string{}, nil, true},
+		{map[string]string{"session": "abc"}, []*http.Cookie{{Name: "session", Value: "abc"}}, true},
+		{map[string]string{"session": "^a.c$"}, []*http.Cookie{{Name: "session", Value: "abc"}}, true},
+		{map[string]string{"session": ".*"}, []*http.Cookie{{Name: "lang", Value: "en"}, {Name: "session", Value: ""}}, true},
+		{map[string]string{"session": "abc", "lang": "en"}, []*http.Cookie{{Name: "session", Value: "abc"}}, false},
+		{map[string]string{"session": "^abc$"}, []*http.Cookie{{Name: "session", Value: "abcd"}}, false},
+		{map[string]string{"session": "a[b"}, []*http.Cookie{{Name: "session", Value: "a[b"}}, true},
+		{map[string]string{"session": "a[b"}, []*http.Cookie{{Name: "session", Value: "ab"}}, false},
+		{map[string]string{"session": ".*"}, nil, false},
+	}
+
+	for _, test := range cases {
+		req := &http.Request{Header: make(http.Header)}
+		for _, cookie := range test.cookies {
+			req.AddCookie(cookie)
+		}
+		ereq := NewRequest()
+		for name, value := range test.values {
+			ereq.MatchCookie(name, value)
+		}
+		matches, err := MatchCookies(req, ereq)
+		require.Equal(t, err, nil)
+		require.Equal(t, matches, test.matches)
+	}
+}
+
 func TestMatchQueryParams(t *testing.T) {
 	t.Parallel()
 
@@ -228,6 +266,8 @@ func TestMatchBody(t *testing.T) {
 
 		{`{"foo":"bar","bar":"foo"}`, `{"bar":"foo","foo":"bar"}`, true},
 		{`{"bar":"foo","foo":{"two":"three","three":"two"}}`, `{"foo":{"three":"two","two":"three"},"bar":"foo"}`, true},
+		{`[{"id": 1}, {"id": 2}]`, `[{"id":1},{"id":2}]`, true},
+		{`{"foo": "bar"}`, `{"foo":"bar","bar":"foo"}`, false},
 	}
 
 	for _, test := range cases {
@@ -239,6 +279,118 @@ func TestMatchBody(t *testing.T) {
 	}
 }
 
+func TestMatchBody_PartialJSON(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value       string
+		body        string
+		ignoreOrder bool
+		matches     bool
+	}{
+		{`{"foo": "bar"}`, `{"foo":"bar","bar":"foo"}`, false, true},
+		{`{"user": {"id": 1}}`, `{"user":{"id":1,"name":"foo"},"admin":true}`, false, true},
+		{`{"user": {"id": 2}}`, `{"user":{"id":1,"name":"foo"}}`, false, false},
+		{`{"ids": [1, 2]}`, `{"ids":[1,2],"foo":"bar"}`, false, true},
+		{`{"ids": [2, 1]}`, `{"ids":[1,2],"foo":"bar"}`, false, false},
+		{`{"ids": [2, 1]}`, `{"ids":[1,2],"foo":"bar"}`, true, true},
+		{`{"ids": [1]}`, `{"ids":[1,2]}`, true, false},
+		{`[{"id": 2}, {"id": 1}]`, `[{"id":1,"name":"foo"},{"id":2}]`, true, true},
+	}
+
+	for i, test := range cases {
+		req := &http.Request{Body: createReadCloser([]byte(test.body))}
+		ereq := &Request{BodyBuffer: []byte(test.value), JSONPartial: true, ArrayOrderIgnored: test.ignoreOrder}
+		matches, err := MatchBody(req, ereq)
+		require.Equal(t, err, nil, i)
+		require.Equal(t, matches, test.matches, i)
+	}
+}
+
+func TestMatchForm(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+	writer.WriteField("name", "foo")
+	file, _ := writer.CreateFormFile("avatar", "me.png")
+	file.Write([]byte("PNG data"))
+	writer.Close()
+
+	cases := []struct {
+		contentType string
+		body        string
+		fields      map[string]string
+		files       []*MultipartFile
+		matches     bool
+	}{
+		{"application/x-www-form-urlencoded", "name=foo&age=42", map[string]string{"name": "foo"}, nil, true},
+		{"application/x-www-form-urlencoded", "name=foo&age=42", map[string]string{"age": "^[0-9]+$"}, nil, true},
+		{"application/x-www-form-urlencoded", "name=foo&age=42", map[string]string{"name": "bar"}, nil, false},
+		{"application/x-www-form-urlencoded", "name=foo", map[string]string{"age": ".*"}, nil, false},
+		{"application/json", `{"name":"foo"}`, map[string]string{"name": "foo"}, nil, false},
+		{writer.FormDataContentType(), buf.String(), map[string]string{"name": "^foo$"}, nil, true},
+		{writer.FormDataContentType(), buf.String(), nil, []*MultipartFile{{Field: "avatar", Filename: `\.png$`, Content: "^PNG"}}, true},
+		{writer.FormDataContentType(), buf.String(), nil, []*MultipartFile{{Field: "avatar"}}, true},
+		{writer.FormDataContentType(), buf.String(), nil, []*MultipartFile{{Field: "avatar", Filename: `\.jpg$`}}, false},
+		{writer.FormDataContentType(), buf.String(), nil, []*MultipartFile{{Field: "avatar", Content: "GIF"}}, false},
+		{writer.FormDataContentType(), buf.String(), nil, []*MultipartFile{{Field: "name"}}, false},
+	}
+
+	for i, test := range cases {
+		req := &http.Request{
+			Header: http.Header{"Content-Type": []string{test.contentType}},
+			Body:   createReadCloser([]byte(test.body)),
+		}
+		ereq := &Request{FormFields: test.fields, MultipartFiles: test.files}
+		matches, err := MatchForm(req, ereq)
+		require.Equal(t, err, nil, i)
+		require.Equal(t, matches, test.matches, i)
+
+		// The body is restored for the next matchers
+		data, _ := io.ReadAll(req.Body)
+		require.Equal(t, test.body, string(data))
+	}
+}
+
+func TestMatchJSONPaths(t *testing.T) {
+	t.Parallel()
+
+	body := `{"user":{"id":42,"name":"foo","tags":["a","b"]},"items":[{"sku":"x1"},{"sku":"y2"}]}`
+	cases := []struct {
+		path    string
+		value   interface{}
+		matches bool
+	}{
+		{"$.user.id", 42, true},
+		{"$.user.id", 43, false},
+		{"$.user.id", "42", false},
+		{"$['user']['name']", "foo", true},
+		{"$.user.name", regexp.MustCompile("^f"), true},
+		{"$.user.id", regexp.MustCompile("^4[0-9]$"), true},
+		{"$.user.tags", []string{"a", "b"}, true},
+		{"$.user.tags[-1]", "b", true},
+		{"$.items[1].sku", "y2", true},
+		{"$.items[*].sku", "x1", true},
+		{"$.items[*].sku", "z3", false},
+		{"$.user.*", "foo", true},
+		{"$.missing", nil, false},
+		{"$.items[5].sku", "x1", false},
+	}
+
+	for i, test := range cases {
+		req := &http.Request{Body: createReadCloser([]byte(body))}
+		ereq := &Request{JSONPaths: map[string]interface{}{test.path: test.value}}
+		matches, err := MatchJSONPaths(req, ereq)
+		require.Equal(t, err, nil, i)
+		require.Equal(t, matches, test.matches, i)
+
+		// The body is restored for the next matchers
+		data, _ := io.ReadAll(req.Body)
+		require.Equal(t, body, string(data))
+	}
+}
+
 func TestMatchBody_MatchType(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,7 @@
 package httpmock
 
 import (
+	"fmt"
 	"net/http"
 	"sync"
 )
@@ -47,6 +48,9 @@ type Mocker struct {
 
 	// response stores the mock Response to use in case of match.
 	response *Response
+
+	// calls stores the requests matched by the current mock.
+	calls []*Call
 }
 
 type disabler struct {
@@ -117,17 +121,27 @@ func (m *Mocker) Response() *Response {
 // Match matches the given http.Request with the current Request
 // mock expectation, returning true if matches.
 func (m *Mocker) Match(req *http.Request) (bool, error) {
+	result, err := m.matchResult(req)
+	return result != nil && result.Matches(), err
+}
+
+// matchResult matches the given http.Request with the current Request mock expectation,
+// returning the match result, or nil if the mock is disabled.
+func (m *Mocker) matchResult(req *http.Request) (*MatchResult, error) {
 	if m.disabler.isDisabled() {
-		return false, nil
+		return nil, nil
 	}
 
 	// Filter
 	for _, filter := range m.request.Filters {
 		if !filter(req) {
-			return false, nil
+			return &MatchResult{Mock: m, Failed: []MatchFailure{{Matcher: funcName(filter), Reason: "filtered out"}}}, nil
 		}
 	}
 
+	// Keep the original request for the calls history
+	orig := req
+
 	// Map
 	for _, mapper := range m.request.Mappers {
 		if treq := mapper(req); treq != nil {
@@ -135,13 +149,31 @@ func (m *Mocker) Match(req *http.Request) (bool, error) {
 		}
 	}
 
-	// Match
-	matches, err := m.matcher.Match(req, m.request)
-	if matches {
-		m.decrement()
+	// Match, recording the matcher functions results when supported
+	var result *MatchResult
+	var err error
+	if matcher, ok := m.matcher.(*MockMatcher); ok {
+		result, err = matcher.MatchResult(req, m.request)
+		if result != nil {
+			result.Mock = m
+		}
+	} else {
+		var matches bool
+		matches, err = m.matcher.Match(req, m.request)
+		result = &MatchResult{Mock: m}
+		if !matches {
+			result.Failed = []MatchFailure{{Matcher: fmt.Sprintf("%T", m.matcher)}}
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return matches, err
+	if result.Matches() {
+		m.decrement()
+		m.track(orig)
+	}
+	return result, nil
 }
 
 // SetMatcher sets a new matcher implementation
@@ -156,6 +188,22 @@ func (m *Mocker) AddMatcher(fn MatchFunc) {
 	m.matcher.Add(fn)
 }
 
+// Calls returns the requests matched by the current mock, in arrival order.
+func (m *Mocker) Calls() []*Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]*Call{}, m.calls...)
+}
+
+// track records the given matched request in the mock calls.
+func (m *Mocker) track(req *http.Request) {
+	call := newCall(req, m)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.calls = append(m.calls, call)
+}
+
 // decrement decrements the current mock Request counter.
 func (m *Mocker) decrement() {
 	if m.request.Persisted {
@@ -0,0 +1,414 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIMethods stores the HTTP methods of the OpenAPI path item operations.
+var openAPIMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// pathTemplate matches the templated segments of an OpenAPI path, e.g. {id}.
+var pathTemplate = regexp.MustCompile(`\{[^{}/]+\}`)
+
+// OpenAPI represents the subset of an OpenAPI 3 document used to generate mocks.
+type OpenAPI struct {
+	OpenAPI    string                      `json:"openapi" yaml:"openapi"`
+	Servers    []OpenAPIServer             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]*OpenAPIPathItem `json:"paths" yaml:"paths"`
+	Components OpenAPIComponents           `json:"components,omitempty" yaml:"components,omitempty"`
+
+	// routesOnce is used to compile the paths patterns once, on the first validated request.
+	routesOnce sync.Once
+
+	// routes stores the compiled paths patterns used to validate the requests, see findOperation.
+	routes []openAPIRoute
+}
+
+// OpenAPIServer represents a server of an OpenAPI document.
+type OpenAPIServer struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// OpenAPIComponents represents the reusable objects of an OpenAPI document.
+type OpenAPIComponents struct {
+	Schemas       map[string]*OpenAPISchema      `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Responses     map[string]*OpenAPIResponse    `json:"responses,omitempty" yaml:"responses,omitempty"`
+	Parameters    map[string]*OpenAPIParameter   `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Examples      map[string]*OpenAPIExample     `json:"examples,omitempty" yaml:"examples,omitempty"`
+	RequestBodies map[string]*OpenAPIRequestBody `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
+}
+
+// OpenAPIPathItem represents the operations available on a single path.
+type OpenAPIPathItem struct {
+	Parameters []*OpenAPIParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Get        *OpenAPIOperation   `json:"get,omitempty" yaml:"get,omitempty"`
+	Put        *OpenAPIOperation   `json:"put,omitempty" yaml:"put,omitempty"`
+	Post       *OpenAPIOperation   `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete     *OpenAPIOperation   `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options    *OpenAPIOperation   `json:"options,omitempty" yaml:"options,omitempty"`
+	Head       *OpenAPIOperation   `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch      *OpenAPIOperation   `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace      *OpenAPIOperation   `json:"trace,omitempty" yaml:"trace,omitempty"`
+}
+
+// OpenAPIOperation represents a single API operation on a path.
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses" yaml:"responses"`
+}
+
+// OpenAPIParameter represents an operation parameter.
+type OpenAPIParameter struct {
+	Ref      string         `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name     string         `json:"name,omitempty" yaml:"name,omitempty"`
+	In       string         `json:"in,omitempty" yaml:"in,omitempty"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody represents the request body of an operation.
+type OpenAPIRequestBody struct {
+	Ref      string                       `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Required bool                         `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]*OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// OpenAPIResponse represents a single response of an operation.
+type OpenAPIResponse struct {
+	Ref         string                       `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                       `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// OpenAPIMediaType represents the schema and examples of a content type.
+type OpenAPIMediaType struct {
+	Schema   *OpenAPISchema             `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example  interface{}                `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples map[string]*OpenAPIExample `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+// OpenAPIExample represents a named example.
+type OpenAPIExample struct {
+	Ref   string      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// OpenAPISchema represents the subset of a JSON schema supported by httpmock.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	AdditionalProperties interface{}               `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Required             []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Enum                 []interface{}             `json:"enum,omitempty" yaml:"enum,omitempty"`
+	AllOf                []*OpenAPISchema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	AnyOf                []*OpenAPISchema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	OneOf                []*OpenAPISchema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Example              interface{}               `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// LoadOpenAPI registers a persistent mock for every operation of the given
+// OpenAPI 3 document for the given server, see OpenAPI.Mocks.
+// The test fails if the document cannot be read.
+func LoadOpenAPI(t *testing.T, server *httptest.Server, path string) []*Request {
+	t.Helper()
+
+	doc, err := ReadOpenAPI(path)
+	if err != nil {
+		t.Fatalf("httpmock: cannot load OpenAPI document from %s: %v", path, err)
+		return nil
+	}
+	return doc.Mocks(server.URL)
+}
+
+// ReadOpenAPI reads the OpenAPI 3 document stored in the given JSON or YAML file.
+func ReadOpenAPI(path string) (*OpenAPI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON documents are valid YAML documents as well
+	doc := &OpenAPI{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("gock: unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+	return doc, nil
+}
+
+// Mocks registers a persistent mock for every operation of the document for the given URL.
+// Templated path segments, e.g. /users/{id}, match any segment value, and the operation
+// replies its first successful documented response, using its example as body.
+func (d *OpenAPI) Mocks(uri string) []*Request {
+	reqs := []*Request{}
+	for _, path := range d.sortedPaths() {
+		item := d.Paths[path]
+		for _, method := range openAPIMethods {
+			op := item.operation(method)
+			if op == nil {
+				continue
+			}
+			req := New(uri)
+			req.method(method, d.pathPattern(path))
+			req.Persist()
+			d.reply(req, op)
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
+
+// basePath returns the URL path of the first document server, if any.
+func (d *OpenAPI) basePath() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	u, err := url.Parse(d.Servers[0].URL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+// sortedPaths returns the document paths, the ones with less templated segments first,
+// so /users/me is matched before /users/{id}.
+func (d *OpenAPI) sortedPaths() []string {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(pathTemplate.FindAllString(paths[i], -1)) < len(pathTemplate.FindAllString(paths[j], -1))
+	})
+	return paths
+}
+
+// pathPattern returns the regular expression matching the given templated path.
+func (d *OpenAPI) pathPattern(path string) string {
+	parts := pathTemplate.Split(d.basePath()+path, -1)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return "^" + strings.Join(parts, "[^/]+") + "$"
+}
+
+// reply defines the mock response based on the given operation.
+func (d *OpenAPI) reply(req *Request, op *OpenAPIOperation) {
+	status, res := d.successResponse(op)
+	mres := req.Reply(status)
+	if res == nil {
+		return
+	}
+
+	kind, media := contentMedia(res.Content)
+	if media == nil {
+		return
+	}
+	mres.SetHeader("Content-Type", kind)
+
+	example := d.mediaExample(media)
+	if example == nil {
+		return
+	}
+	if s, ok := example.(string); ok && !strings.Contains(kind, "json") {
+		mres.BodyString(s)
+		return
+	}
+	mres.BodyBuffer, mres.Error = json.Marshal(example)
+}
+
+// successResponse returns the first successful response documented by the given operation.
+func (d *OpenAPI) successResponse(op *OpenAPIOperation) (int, *OpenAPIResponse) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	status, code := 0, ""
+	for _, c := range codes {
+		s := openAPIStatus(c)
+		if s >= 200 && s < 300 {
+			status, code = s, c
+			break
+		}
+	}
+	if status == 0 && op.Responses["default"] != nil {
+		status, code = http.StatusOK, "default"
+	}
+	if status == 0 {
+		return http.StatusOK, nil
+	}
+	return status, d.response(op.Responses[code])
+}
+
+// mediaExample returns the documented example of the given media type.
+func (d *OpenAPI) mediaExample(media *OpenAPIMediaType) interface{} {
+	if media.Example != nil {
+		return media.Example
+	}
+	names := make([]string, 0, len(media.Examples))
+	for name := range media.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if example := d.example(media.Examples[name]); example != nil && example.Value != nil {
+			return example.Value
+		}
+	}
+	return d.schemaExample(media.Schema, 0)
+}
+
+// schemaExample builds an example based on the examples documented by the given schema and its properties.
+func (d *OpenAPI) schemaExample(schema *OpenAPISchema, depth int) interface{} {
+	schema = d.schema(schema)
+	if schema == nil || depth > 10 {
+		return nil
+	}
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	switch {
+	case len(schema.Properties) > 0:
+		obj := map[string]interface{}{}
+		for name, prop := range schema.Properties {
+			if value := d.schemaExample(prop, depth+1); value != nil {
+				obj[name] = value
+			}
+		}
+		if len(obj) == 0 {
+			return nil
+		}
+		return obj
+	case schema.Items != nil:
+		if value := d.schemaExample(schema.Items, depth+1); value != nil {
+			return []interface{}{value}
+		}
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+	return nil
+}
+
+// schema resolves the given schema reference, if any.
+func (d *OpenAPI) schema(schema *OpenAPISchema) *OpenAPISchema {
+	for i := 0; schema != nil && schema.Ref != "" && i < 10; i++ {
+		schema = d.Components.Schemas[refName(schema.Ref, "schemas")]
+	}
+	return schema
+}
+
+// response resolves the given response reference, if any.
+func (d *OpenAPI) response(res *OpenAPIResponse) *OpenAPIResponse {
+	if res != nil && res.Ref != "" {
+		return d.Components.Responses[refName(res.Ref, "responses")]
+	}
+	return res
+}
+
+// parameter resolves the given parameter reference, if any.
+func (d *OpenAPI) parameter(param *OpenAPIParameter) *OpenAPIParameter {
+	if param != nil && param.Ref != "" {
+		return d.Components.Parameters[refName(param.Ref, "parameters")]
+	}
+	return param
+}
+
+// requestBody resolves the given request body reference, if any.
+func (d *OpenAPI) requestBody(body *OpenAPIRequestBody) *OpenAPIRequestBody {
+	if body != nil && body.Ref != "" {
+		return d.Components.RequestBodies[refName(body.Ref, "requestBodies")]
+	}
+	return body
+}
+
+// example resolves the given example reference, if any.
+func (d *OpenAPI) example(example *OpenAPIExample) *OpenAPIExample {
+	if example != nil && example.Ref != "" {
+		return d.Components.Examples[refName(example.Ref, "examples")]
+	}
+	return example
+}
+
+// operation returns the path item operation of the given HTTP method.
+func (p *OpenAPIPathItem) operation(method string) *OpenAPIOperation {
+	switch method {
+	case "GET":
+		return p.Get
+	case "PUT":
+		return p.Put
+	case "POST":
+		return p.Post
+	case "DELETE":
+		return p.Delete
+	case "OPTIONS":
+		return p.Options
+	case "HEAD":
+		return p.Head
+	case "PATCH":
+		return p.Patch
+	case "TRACE":
+		return p.Trace
+	}
+	return nil
+}
+
+// refName returns the component name of the given local reference, e.g. #/components/schemas/User.
+func refName(ref, kind string) string {
+	return strings.TrimPrefix(ref, "#/components/"+kind+"/")
+}
+
+// openAPIStatus returns the status code of the given response code, e.g. 201 or 2XX.
+func openAPIStatus(code string) int {
+	if len(code) == 3 && strings.HasSuffix(strings.ToUpper(code), "XX") {
+		code = code[:1] + "00"
+	}
+	status, _ := strconv.Atoi(code)
+	return status
+}
+
+// contentMedia returns the preferred content type of the given content, JSON if available.
+func contentMedia(content map[string]*OpenAPIMediaType) (string, *OpenAPIMediaType) {
+	kinds := make([]string, 0, len(content))
+	for kind := range content {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		if strings.Contains(kind, "json") {
+			return kind, content[kind]
+		}
+	}
+	if len(kinds) == 0 {
+		return "", nil
+	}
+	return kinds[0], content[kinds[0]]
+}
@@ -0,0 +1,82 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	reqs := LoadOpenAPI(t, s, "testdata/openapi/users.yaml")
+	require.Len(t, reqs, 6)
+
+	get := func(path string) (*http.Response, string) {
+		res, err := http.Get(s.URL + path)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(res.Body)
+		return res, string(body)
+	}
+
+	res, body := get("/v1/users?page=2")
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	require.JSONEq(t, `[{"id":1,"name":"foo"}]`, body)
+
+	res, body = get("/v1/users/42")
+	require.Equal(t, 200, res.StatusCode)
+	require.JSONEq(t, `{"id":1,"name":"foo"}`, body)
+
+	res, body = get("/v1/users/me")
+	require.Equal(t, 200, res.StatusCode)
+	require.JSONEq(t, `{"id":0,"name":"me"}`, body)
+
+	res, body = get("/v1/health")
+	require.Equal(t, 200, res.StatusCode)
+	require.Equal(t, "text/plain", res.Header.Get("Content-Type"))
+	require.Equal(t, "OK", body)
+
+	res, _ = get("/v1/users/42/posts")
+	require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+	CleanUnmatchedRequest(t)
+
+	res, err := http.Post(s.URL+"/v1/users", "application/json", strings.NewReader(`{"name":"bar"}`))
+	require.NoError(t, err)
+	body2, _ := io.ReadAll(res.Body)
+	require.Equal(t, 201, res.StatusCode)
+	require.JSONEq(t, `{"id":2,"name":"bar"}`, string(body2))
+
+	req, _ := http.NewRequest("DELETE", s.URL+"/v1/users/42", nil)
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 204, res.StatusCode)
+
+	// Persistent mocks can be called again
+	res, _ = get("/v1/users/7")
+	require.Equal(t, 200, res.StatusCode)
+}
+
+func TestReadOpenAPIErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadOpenAPI("testdata/openapi/missing.yaml")
+	require.Error(t, err)
+
+	_, err = ReadOpenAPI("testdata/mocks/errors.json")
+	require.EqualError(t, err, `gock: unsupported OpenAPI version ""`)
+}
+
+func TestOpenAPIPathPattern(t *testing.T) {
+	t.Parallel()
+
+	doc := &OpenAPI{Servers: []OpenAPIServer{{URL: "/api/"}}}
+	require.Equal(t, `^/api/users/[^/]+/posts\.json$`, doc.pathPattern("/users/{id}/posts.json"))
+	require.Equal(t, 201, openAPIStatus("201"))
+	require.Equal(t, 200, openAPIStatus("2XX"))
+	require.Equal(t, 0, openAPIStatus("default"))
+}
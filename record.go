@@ -0,0 +1,256 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+)
+
+// hopHeaders stores the header fields which are not recorded in fixtures
+// since they only make sense for a single connection.
+var hopHeaders = []string{
+	"Connection",
+	"Content-Length",
+	"Keep-Alive",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Fixture represents a recorded HTTP exchange which can be replayed as a mock.
+type Fixture struct {
+	// Request stores the recorded outgoing request.
+	Request FixtureRequest `json:"request"`
+
+	// Response stores the recorded upstream response.
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest represents the recorded request fields used for matching.
+type FixtureRequest struct {
+	Method       string      `json:"method"`
+	Path         string      `json:"path"`
+	Query        string      `json:"query,omitempty"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// FixtureResponse represents the recorded response fields used for replying.
+type FixtureResponse struct {
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// Recorder forwards unmatched requests to a real upstream server
+// and records every exchange as a Fixture.
+type Recorder struct {
+	// mutex is used to make recorder thread-safe of concurrent uses across goroutines.
+	mutex sync.Mutex
+
+	// Transport stores the transport used to reach the upstream, http.DefaultTransport by default.
+	Transport http.RoundTripper
+
+	// upstream stores the base URL where unmatched requests are forwarded to.
+	upstream *url.URL
+
+	// path stores the fixture file path.
+	path string
+
+	// fixtures stores the recorded and replayed fixtures.
+	fixtures []*Fixture
+}
+
+// NewRecorder creates a new Recorder which forwards requests to the given upstream
+// URL and persists the recorded fixtures in the given file path.
+// Fixtures already stored in the file are kept and saved again on Save.
+func NewRecorder(upstream, path string) (*Recorder, error) {
+	u, err := url.Parse(normalizeURI(upstream))
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures, err := LoadFixtures(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return &Recorder{
+		Transport: http.DefaultTransport,
+		upstream:  u,
+		path:      path,
+		fixtures:  fixtures,
+	}, nil
+}
+
+// Fixtures returns the fixtures known by the recorder.
+func (r *Recorder) Fixtures() []*Fixture {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.fixtures
+}
+
+// RoundTrip forwards the given request to the upstream and records the exchange.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body = createReadCloser(body)
+	}
+
+	u := *req.URL
+	u.Scheme = r.upstream.Scheme
+	u.Host = r.upstream.Host
+	u.Path = r.upstream.Path + req.URL.Path
+	u.RawPath = ""
+
+	oreq, err := http.NewRequestWithContext(req.Context(), req.Method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	oreq.Header = req.Header.Clone()
+	removeHopHeaders(oreq.Header)
+
+	res, err := r.Transport.RoundTrip(oreq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{
+		Request: FixtureRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.RawQuery,
+			Header: req.Header.Clone(),
+		},
+		Response: FixtureResponse{
+			Status: res.StatusCode,
+			Header: res.Header.Clone(),
+		},
+	}
+	fixture.Request.Body, fixture.Request.BodyEncoding = encodeBody(body)
+	fixture.Response.Body, fixture.Response.BodyEncoding = encodeBody(resBody)
+	removeHopHeaders(fixture.Request.Header)
+	removeHopHeaders(fixture.Response.Header)
+
+	r.mutex.Lock()
+	r.fixtures = append(r.fixtures, fixture)
+	r.mutex.Unlock()
+
+	res.Body = createReadCloser(resBody)
+	res.ContentLength = int64(len(resBody))
+	res.Request = req
+	return res, nil
+}
+
+// Save writes the recorded fixtures into the fixture file.
+func (r *Recorder) Save() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(r.fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, EOL), 0o644)
+}
+
+// LoadFixtures reads the fixtures stored in the given file path.
+func LoadFixtures(path string) ([]*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []*Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+// Mock registers a new mock for the given server URL which replays the fixture.
+// The replayed mock matches the recorded method, path, query params and exact body.
+func (f *Fixture) Mock(uri string) *Request {
+	req := New(uri)
+	req.method(f.Request.Method, "^"+regexp.QuoteMeta(f.Request.Path)+"$")
+
+	query, err := url.ParseQuery(f.Request.Query)
+	if err != nil {
+		req.Error = err
+	}
+	for key, values := range query {
+		req.MatchParam(key, "^"+regexp.QuoteMeta(values[0])+"$")
+	}
+
+	// Recorded bodies are matched as is, whatever their content type
+	body, err := decodeBody(f.Request.Body, f.Request.BodyEncoding)
+	if err != nil {
+		req.Error = err
+	}
+	if len(body) > 0 {
+		req.AddMatcher(matchBodyBytes(body))
+	}
+
+	res := req.Reply(f.Response.Status)
+	for key, values := range f.Response.Header {
+		for _, value := range values {
+			res.AddHeader(key, value)
+		}
+	}
+	res.BodyBuffer, res.Error = decodeBody(f.Response.Body, f.Response.BodyEncoding)
+
+	return req
+}
+
+// matchBodyBytes returns a matcher function matching the request bodies equal to the given one.
+func matchBodyBytes(body []byte) MatchFunc {
+	return func(req *http.Request, ereq *Request) (bool, error) {
+		actual, err := readBody(req, "")
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(actual, body), nil
+	}
+}
+
+func removeHopHeaders(header http.Header) {
+	for _, key := range hopHeaders {
+		header.Del(key)
+	}
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	if encoding == "base64" {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
@@ -0,0 +1,120 @@
+package httpmock
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		rw.Header().Set("X-Upstream", "yes")
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(r.URL.Path + "?" + r.URL.RawQuery + " " + string(body)))
+	}))
+	defer upstream.Close()
+
+	fixtures := filepath.Join(t.TempDir(), "fixtures.json")
+
+	t.Run("record", func(t *testing.T) {
+		s := Server(t, WithRecording(upstream.URL, fixtures))
+
+		res, err := http.Post(s.URL+"/users?page=2", "text/plain", bytes.NewBufferString("foo"))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(res.Body)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		require.Equal(t, "yes", res.Header.Get("X-Upstream"))
+		require.Equal(t, "/users?page=2 foo", string(body))
+	})
+
+	require.Equal(t, 1, calls)
+	recorded, err := LoadFixtures(fixtures)
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	require.Equal(t, "POST", recorded[0].Request.Method)
+	require.Equal(t, "/users", recorded[0].Request.Path)
+	require.Equal(t, "page=2", recorded[0].Request.Query)
+	require.Equal(t, "foo", recorded[0].Request.Body)
+	require.Equal(t, http.StatusCreated, recorded[0].Response.Status)
+
+	upstream.Close()
+
+	t.Run("replay", func(t *testing.T) {
+		s := Server(t, WithRecording(upstream.URL, fixtures))
+
+		res, err := http.Post(s.URL+"/users?page=2", "text/plain", bytes.NewBufferString("foo"))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(res.Body)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		require.Equal(t, "yes", res.Header.Get("X-Upstream"))
+		require.Equal(t, "/users?page=2 foo", string(body))
+		require.True(t, IsDone(t))
+	})
+
+	require.Equal(t, 1, calls)
+}
+
+func TestRecordAndReplayBinaryBody(t *testing.T) {
+	t.Parallel()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write(body)
+	}))
+	defer upstream.Close()
+
+	fixtures := filepath.Join(t.TempDir(), "fixtures.json")
+	payload := []byte{0xff, 0xfe, 0x00, 0x01}
+
+	t.Run("record", func(t *testing.T) {
+		s := Server(t, WithRecording(upstream.URL, fixtures))
+
+		res, err := http.Post(s.URL+"/upload", "application/octet-stream", bytes.NewReader(payload))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+	})
+
+	recorded, err := LoadFixtures(fixtures)
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	require.Equal(t, "base64", recorded[0].Request.BodyEncoding)
+
+	upstream.Close()
+
+	t.Run("replay", func(t *testing.T) {
+		s := Server(t, WithRecording(upstream.URL, fixtures))
+
+		res, err := http.Post(s.URL+"/upload", "application/octet-stream", bytes.NewReader([]byte{0xff}))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotImplemented, res.StatusCode)
+
+		res, err = http.Post(s.URL+"/upload", "application/octet-stream", bytes.NewReader(payload))
+		require.NoError(t, err)
+		body, _ := io.ReadAll(res.Body)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		require.Equal(t, payload, body)
+		require.True(t, IsDone(t))
+	})
+}
+
+func TestFixtureBinaryBody(t *testing.T) {
+	t.Parallel()
+
+	body, encoding := encodeBody([]byte{0xff, 0xfe})
+	require.Equal(t, "base64", encoding)
+
+	decoded, err := decodeBody(body, encoding)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff, 0xfe}, decoded)
+}
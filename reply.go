@@ -0,0 +1,69 @@
+package httpmock
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+)
+
+// ErrNilResponse is returned when a reply handler returns neither a response nor an error.
+var ErrNilResponse = errors.New("gock: reply handler returned a nil response")
+
+// ReplyHandlerFunc represents the function interface computing a mock response from the intercepted request.
+type ReplyHandlerFunc func(*http.Request) (*http.Response, error)
+
+// ReplyHandler defines a function computing the mock response from every matched request,
+// e.g. to echo identifiers sent in the request body. The returned response can be further
+// customized via the Response DSL, e.g. with a delay, headers or a fault, and its status
+// and body are only replaced when explicitly defined.
+func (r *Request) ReplyHandler(handler ReplyHandlerFunc) *Response {
+	res := r.current()
+	res.ReplyHandler = handler
+	return res
+}
+
+// ReplyHTTPHandler defines an http.Handler serving every matched request, so fake handlers
+// written for httptest can be plugged in for a single route.
+func (r *Request) ReplyHTTPHandler(handler http.Handler) *Response {
+	return r.ReplyHandler(func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		res := rec.Result()
+		if res.ContentLength < 0 && len(res.Trailer) == 0 {
+			res.ContentLength = int64(rec.Body.Len())
+		}
+		return res, nil
+	})
+}
+
+// replyHandlerResponse computes the base response of the given request via the given handler,
+// completing the fields it may have omitted.
+func replyHandlerResponse(req *http.Request, handler ReplyHandlerFunc) (*http.Response, error) {
+	res, err := handler(req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ErrNilResponse
+	}
+
+	base := createResponse(req)
+	if res.StatusCode == 0 {
+		res.StatusCode = http.StatusOK
+	}
+	if res.Status == "" {
+		res.Status = strconv.Itoa(res.StatusCode) + " " + http.StatusText(res.StatusCode)
+	}
+	if res.Proto == "" {
+		res.Proto, res.ProtoMajor, res.ProtoMinor = base.Proto, base.ProtoMajor, base.ProtoMinor
+	}
+	if res.Header == nil {
+		res.Header = base.Header
+	}
+	if res.Body == nil {
+		res.Body = base.Body
+	}
+	res.Request = req
+	return res, nil
+}
@@ -0,0 +1,120 @@
+package httpmock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplyHandler(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Post("/users").Persist().ReplyHandler(func(req *http.Request) (*http.Response, error) {
+		var user map[string]interface{}
+		if err := json.NewDecoder(req.Body).Decode(&user); err != nil {
+			return nil, err
+		}
+		user["id"] = 1
+		body, _ := json.Marshal(user)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(string(body))),
+		}, nil
+	}).SetHeader("X-Foo", "bar")
+
+	for _, name := range []string{"foo", "bar"} {
+		res, err := http.Post(s.URL+"/users", "application/json", strings.NewReader(`{"name":"`+name+`"}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		require.Equal(t, "bar", res.Header.Get("X-Foo"))
+		body, _ := io.ReadAll(res.Body)
+		require.JSONEq(t, `{"id":1,"name":"`+name+`"}`, string(body))
+	}
+}
+
+func TestReplyHandlerOverrides(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/foo").ReplyHandler(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{}, nil
+	}).Status(http.StatusAccepted).BodyString("foo").Delay(20 * time.Millisecond)
+
+	start := time.Now()
+	res, err := client.Get(s.URL + "/foo")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(20*time.Millisecond))
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+	require.NotNil(t, res.Request)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, "foo", string(body))
+}
+
+func TestReplyHandlerHeaders(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/foo").ReplyHandler(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/plain"}, "X-Foo": {"foo"}, "X-Bar": {"bar"}},
+			Body:       io.NopCloser(strings.NewReader("foo")),
+		}, nil
+	}).JSON(map[string]string{"foo": "bar"}).SetHeader("X-Foo", "baz")
+
+	res, err := http.Get(s.URL + "/foo")
+	require.NoError(t, err)
+	require.Equal(t, []string{"application/json"}, res.Header.Values("Content-Type"))
+	require.Equal(t, []string{"baz"}, res.Header.Values("X-Foo"))
+	require.Equal(t, []string{"bar"}, res.Header.Values("X-Bar"))
+}
+
+func TestReplyHandlerError(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/error").ReplyHandler(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("foo")
+	})
+	New(s.URL).Get("/nil").ReplyHandler(func(req *http.Request) (*http.Response, error) {
+		return nil, nil
+	})
+
+	_, err := client.Get(s.URL + "/error")
+	require.ErrorContains(t, err, "foo")
+	_, err = client.Get(s.URL + "/nil")
+	require.ErrorIs(t, err, ErrNilResponse)
+}
+
+func TestReplyHTTPHandler(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/users").Persist().ReplyHTTPHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/plain")
+		rw.WriteHeader(http.StatusPartialContent)
+		fmt.Fprintf(rw, "page %s", r.URL.Query().Get("page"))
+	}))
+
+	res, err := client.Get(s.URL + "/users?page=2")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPartialContent, res.StatusCode)
+	require.Equal(t, int64(6), res.ContentLength)
+	body, _ := io.ReadAll(res.Body)
+	require.Equal(t, "page 2", string(body))
+
+	res, err = http.Get(s.URL + "/users?page=3")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPartialContent, res.StatusCode)
+	require.Equal(t, "text/plain", res.Header.Get("Content-Type"))
+	body, _ = io.ReadAll(res.Body)
+	require.Equal(t, "page 3", string(body))
+}
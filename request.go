@@ -24,6 +24,15 @@ type Request struct {
 	// Response stores the current Response instance for the current matches Request.
 	Response *Response
 
+	// Responses stores the ordered responses sequence, if any, replied one per matched request.
+	Responses []*Response
+
+	// builder stores the response of the sequence currently defined via the Reply DSL, if any.
+	builder *Response
+
+	// SequenceMode stores the behaviour once the responses sequence is exhausted.
+	SequenceMode SequenceMode
+
 	// Error stores the latest mock request configuration error.
 	Error error
 
@@ -57,6 +66,30 @@ type Request struct {
 	// BodyBuffer stores the body data to match.
 	BodyBuffer []byte
 
+	// JSONPartial stores if the JSON body should match as a subset of the request body.
+	JSONPartial bool
+
+	// ArrayOrderIgnored stores if the JSON arrays items may be in any order.
+	ArrayOrderIgnored bool
+
+	// JSONPaths stores the JSONPath expressions and their expected values to match.
+	JSONPaths map[string]interface{}
+
+	// FormFields stores the form fields and their value regular expressions to match.
+	FormFields map[string]string
+
+	// MultipartFiles stores the multipart form files to match.
+	MultipartFiles []*MultipartFile
+
+	// GraphQLOperation stores the GraphQL operation name to match.
+	GraphQLOperation string
+
+	// GraphQLQuery stores the normalized GraphQL query document to match.
+	GraphQLQuery string
+
+	// GraphQLVariables stores the GraphQL variables to match as a subset.
+	GraphQLVariables map[string]interface{}
+
 	// Mappers stores the request functions mappers used for matching.
 	Mappers []MapRequestFunc
 
@@ -150,7 +183,7 @@ func (r *Request) File(path string) *Request {
 }
 
 // Compression defines the request compression scheme, and enables automatic body decompression.
-// Supports only the "gzip" scheme so far.
+// Supports the "gzip" and "deflate" schemes, and the ones registered via RegisterCodec.
 func (r *Request) Compression(scheme string) *Request {
 	r.Header.Set("Content-Encoding", scheme)
 	r.CompressionScheme = scheme
@@ -166,6 +199,66 @@ func (r *Request) JSON(data interface{}) *Request {
 	return r
 }
 
+// PartialJSON defines the JSON body to match as a subset of the request body,
+// so extra object fields in the request body are ignored.
+func (r *Request) PartialJSON(data interface{}) *Request {
+	r.JSONPartial = true
+	return r.JSON(data)
+}
+
+// IgnoreArrayOrder defines that the JSON arrays items may be in any order
+// when matching the JSON body and the JSONPath values.
+func (r *Request) IgnoreArrayOrder() *Request {
+	r.ArrayOrderIgnored = true
+	return r
+}
+
+// MatchJSONPath defines the value to match at the given JSONPath expression of the JSON
+// request body, e.g. MatchJSONPath("$.user.id", 42). The value may be a *regexp.Regexp,
+// matched against the string representation of the selected value.
+// Supported expressions are $, .key, ['key'], [n] and the .* and [*] wildcards.
+// A mock with an invalid expression never matches, and stores the parse error.
+func (r *Request) MatchJSONPath(path string, value interface{}) *Request {
+	if _, err := parseJSONPath(path); err != nil {
+		r.Error = err
+	}
+	if r.JSONPaths == nil {
+		r.JSONPaths = map[string]interface{}{}
+	}
+	r.JSONPaths[path] = value
+	return r
+}
+
+// MatchFormField defines a form field key and value regular expression to match,
+// either in an URL-encoded or a multipart form body.
+func (r *Request) MatchFormField(key, value string) *Request {
+	if r.FormFields == nil {
+		r.FormFields = map[string]string{}
+	}
+	r.FormFields[key] = value
+	return r
+}
+
+// MatchFormFields defines a map of form field keys and value regular expressions to match.
+func (r *Request) MatchFormFields(fields map[string]string) *Request {
+	for key, value := range fields {
+		r.MatchFormField(key, value)
+	}
+	return r
+}
+
+// FormFieldPresent defines that a form field must be present in the request body.
+func (r *Request) FormFieldPresent(key string) *Request {
+	return r.MatchFormField(key, ".*")
+}
+
+// MatchMultipartFile defines a file of a multipart form body to match, uploaded in the given field.
+// The filename and content are regular expressions, an empty one matches any value.
+func (r *Request) MatchMultipartFile(field, filename, content string) *Request {
+	r.MultipartFiles = append(r.MultipartFiles, &MultipartFile{Field: field, Filename: filename, Content: content})
+	return r
+}
+
 // XML defines the XML body to match based on a given structure.
 func (r *Request) XML(data interface{}) *Request {
 	if r.Header.Get("Content-Type") == "" {
@@ -212,6 +305,23 @@ func (r *Request) MatchHeaders(headers map[string]string) *Request {
 	return r
 }
 
+// MatchCookie defines a new cookie name and value regular expression to match.
+func (r *Request) MatchCookie(name, value string) *Request {
+	for _, cookie := range r.Cookies {
+		if cookie.Name == name {
+			cookie.Value = value
+			return r
+		}
+	}
+	r.Cookies = append(r.Cookies, &http.Cookie{Name: name, Value: value})
+	return r
+}
+
+// CookiePresent defines that a cookie must be present in the request.
+func (r *Request) CookiePresent(name string) *Request {
+	return r.MatchCookie(name, ".*")
+}
+
 // MatchParam defines a new key and value URL query param to match.
 func (r *Request) MatchParam(key, value string) *Request {
 	query := r.URLStruct.Query()
@@ -302,18 +412,19 @@ func (r *Request) Filter(fn FilterRequestFunc) *Request {
 
 // Reply defines the Response status code and returns the mock Response DSL.
 func (r *Request) Reply(status int) *Response {
-	return r.Response.Status(status)
+	return r.current().Status(status)
 }
 
 // ReplyError defines the Response simulated error.
 func (r *Request) ReplyError(err error) *Response {
-	return r.Response.SetError(err)
+	return r.current().SetError(err)
 }
 
 // ReplyFunc allows the developer to define the mock response via a custom function.
 func (r *Request) ReplyFunc(replier func(*Response)) *Response {
-	replier(r.Response)
-	return r.Response
+	res := r.current()
+	replier(res)
+	return res
 }
 
 // See 2 (end of page 4) https://www.ietf.org/rfc/rfc2617.txt
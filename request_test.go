@@ -74,6 +74,45 @@ func TestRequestJSON(t *testing.T) {
 	require.Equal(t, req.Header.Get("Content-Type"), "application/json")
 }
 
+func TestRequestPartialJSON(t *testing.T) {
+	t.Parallel()
+
+	req := NewRequest()
+	req.PartialJSON(map[string]string{"foo": "bar"}).IgnoreArrayOrder()
+	require.Equal(t, string(req.BodyBuffer)[:13], `{"foo":"bar"}`)
+	require.True(t, req.JSONPartial)
+	require.True(t, req.ArrayOrderIgnored)
+}
+
+func TestRequestMatchJSONPath(t *testing.T) {
+	t.Parallel()
+
+	req := NewRequest()
+	req.MatchJSONPath("$.user.id", 42)
+	require.Equal(t, map[string]interface{}{"$.user.id": 42}, req.JSONPaths)
+	require.NoError(t, req.Error)
+
+	req.MatchJSONPath("user.id", 42)
+	require.EqualError(t, req.Error, `gock: invalid JSON path "user.id": must start with $`)
+	require.Contains(t, req.JSONPaths, "user.id")
+}
+
+func TestRequestMatchFormField(t *testing.T) {
+	t.Parallel()
+
+	req := NewRequest()
+	req.MatchFormField("foo", "^bar$").MatchFormFields(map[string]string{"baz": "[0-9]+"}).FormFieldPresent("token")
+	require.Equal(t, map[string]string{"foo": "^bar$", "baz": "[0-9]+", "token": ".*"}, req.FormFields)
+}
+
+func TestRequestMatchMultipartFile(t *testing.T) {
+	t.Parallel()
+
+	req := NewRequest()
+	req.MatchMultipartFile("avatar", `\.png$`, "")
+	require.Equal(t, []*MultipartFile{{Field: "avatar", Filename: `\.png$`}}, req.MultipartFiles)
+}
+
 func TestRequestXML(t *testing.T) {
 	t.Parallel()
 
@@ -139,6 +178,20 @@ func TestRequestHeaderPresent(t *testing.T) {
 	require.Equal(t, req.Header.Get("Mixed-CASE"), ".*")
 }
 
+func TestRequestMatchCookie(t *testing.T) {
+	t.Parallel()
+
+	req := NewRequest()
+	req.MatchCookie("session", "abc")
+	req.MatchCookie("session", "def")
+	req.CookiePresent("lang")
+	require.Len(t, req.Cookies, 2)
+	require.Equal(t, req.Cookies[0].Name, "session")
+	require.Equal(t, req.Cookies[0].Value, "def")
+	require.Equal(t, req.Cookies[1].Name, "lang")
+	require.Equal(t, req.Cookies[1].Value, ".*")
+}
+
 func TestRequestMatchParam(t *testing.T) {
 	t.Parallel()
 
@@ -16,6 +16,13 @@ func Responder(req *http.Request, mock *Response, res *http.Response) (*http.Res
 		return nil, err
 	}
 
+	// Compute the response from the request, if needed
+	if mock.ReplyHandler != nil {
+		if res, err = replyHandlerResponse(req, mock.ReplyHandler); err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		res = createResponse(req)
 	}
@@ -36,10 +43,44 @@ func Responder(req *http.Request, mock *Response, res *http.Response) (*http.Res
 	// Define headers by merging fields
 	res.Header = mergeHeaders(res, mock)
 
-	// Define mock body, if present
-	if len(mock.BodyBuffer) > 0 {
-		res.ContentLength = int64(len(mock.BodyBuffer))
-		res.Body = createReadCloser(mock.BodyBuffer)
+	// Define cookies via Set-Cookie header fields
+	for _, cookie := range mock.Cookies {
+		if v := cookie.String(); v != "" {
+			res.Header.Add("Set-Cookie", v)
+		}
+	}
+
+	// Define mock body, if present, compressing it if needed.
+	// Streamed bodies have an unknown length and are read as slow readers.
+	if len(mock.BodyChunks) > 0 {
+		res.ContentLength = -1
+		res.TransferEncoding = []string{"chunked"}
+		res.Body = newChunkReader(req.Context(), mock.BodyChunks)
+		if mock.CompressionScheme != "" {
+			if res.Body, err = compressStream(res.Body, mock.CompressionScheme); err != nil {
+				return nil, err
+			}
+		}
+	} else if len(mock.BodyBuffer) > 0 {
+		body := mock.BodyBuffer
+		if mock.CompressionScheme != "" {
+			if body, err = compressBody(body, mock.CompressionScheme); err != nil {
+				return nil, err
+			}
+		}
+		res.ContentLength = int64(len(body))
+		res.Body = createReadCloser(body)
+	}
+
+	// Define trailers, announced before the body and defined once it's read
+	if len(mock.Trailers) > 0 {
+		res.Trailer = make(http.Header, len(mock.Trailers))
+		for key := range mock.Trailers {
+			res.Trailer[key] = nil
+		}
+		res.ContentLength = -1
+		res.TransferEncoding = []string{"chunked"}
+		res.Body = newTrailerReader(res.Body, res.Trailer, mock.Trailers)
 	}
 
 	// Apply response mappers
@@ -72,6 +113,11 @@ func Responder(req *http.Request, mock *Response, res *http.Response) (*http.Res
 		return nil, err
 	}
 
+	// Simulate the network fault, if any
+	if mock.NetworkFault != "" {
+		return applyFault(req, mock.NetworkFault, res)
+	}
+
 	return res, err
 }
 
@@ -87,9 +133,13 @@ func createResponse(req *http.Request) *http.Response {
 	}
 }
 
-// mergeHeaders copies the mock headers.
+// mergeHeaders copies the mock headers. The header fields computed by a reply handler
+// are replaced by the ones defined by the mock, instead of being duplicated.
 func mergeHeaders(res *http.Response, mres *Response) http.Header {
 	for key, values := range mres.Header {
+		if mres.ReplyHandler != nil {
+			res.Header.Del(key)
+		}
 		for _, value := range values {
 			res.Header.Add(key, value)
 		}
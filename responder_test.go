@@ -62,6 +62,38 @@ func TestResponderSupportsMultipleHeadersWithSameKey(t *testing.T) {
 	require.Equal(t, res.Header, http.Header{"Set-Cookie": []string{"a=1", "b=2"}})
 }
 
+func TestResponderSetsCookies(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	mres := New(s.URL).Reply(200).
+		SetCookie(&http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true}).
+		SetCookie(&http.Cookie{Name: "lang", Value: "en"})
+	req := &http.Request{}
+
+	res, err := Responder(req, mres, nil)
+	require.Equal(t, err, nil)
+	require.Equal(t, res.Header["Set-Cookie"], []string{"session=abc; Path=/; HttpOnly", "lang=en"})
+
+	cookies := res.Cookies()
+	require.Len(t, cookies, 2)
+	require.Equal(t, cookies[0].Name, "session")
+	require.Equal(t, cookies[0].Value, "abc")
+}
+
+func TestResponderCookiesThroughServer(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).Post("/login").Reply(204).
+		SetCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	res, err := http.Post(s.URL+"/login", "text/plain", nil)
+	require.NoError(t, err)
+	require.Len(t, res.Cookies(), 1)
+	require.Equal(t, res.Cookies()[0].Value, "abc")
+}
+
 func TestResponderError(t *testing.T) {
 	t.Parallel()
 
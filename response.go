@@ -34,12 +34,27 @@ type Response struct {
 	// Headers stores the response headers.
 	Header http.Header
 
+	// Trailers stores the response trailer fields sent after the body.
+	Trailers http.Header
+
 	// Cookies stores the response cookie fields.
 	Cookies []*http.Cookie
 
 	// BodyBuffer stores the array of bytes to use as body.
 	BodyBuffer []byte
 
+	// CompressionScheme stores the Content-Encoding scheme used to compress the body.
+	CompressionScheme string
+
+	// BodyChunks stores the body parts streamed one after the other instead of BodyBuffer, if any.
+	BodyChunks []BodyChunk
+
+	// NetworkFault stores the network fault to simulate, if any.
+	NetworkFault Fault
+
+	// ReplyHandler stores the function computing the base response from the intercepted request, if any.
+	ReplyHandler ReplyHandlerFunc
+
 	// ResponseDelay stores the simulated response delay.
 	ResponseDelay time.Duration
 
@@ -93,6 +108,12 @@ func (r *Response) SetHeaders(headers map[string]string) *Response {
 	return r
 }
 
+// SetCookie adds a new cookie to be sent via Set-Cookie header in the mock response.
+func (r *Response) SetCookie(cookie *http.Cookie) *Response {
+	r.Cookies = append(r.Cookies, cookie)
+	return r
+}
+
 // Body sets the HTTP response body to be used.
 func (r *Response) Body(body io.Reader) *Response {
 	r.BodyBuffer, r.Error = io.ReadAll(body)
@@ -126,6 +147,14 @@ func (r *Response) XML(data interface{}) *Response {
 	return r
 }
 
+// Compression defines the scheme used to compress the response body, e.g. "gzip" or "deflate",
+// and sets the Content-Encoding header. The schemes registered via RegisterCodec are supported as well.
+func (r *Response) Compression(scheme string) *Response {
+	r.Header.Set("Content-Encoding", scheme)
+	r.CompressionScheme = scheme
+	return r
+}
+
 // SetError defines the response simulated error.
 func (r *Response) SetError(err error) *Response {
 	r.Error = err
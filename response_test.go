@@ -82,6 +82,15 @@ func TestResponseSetHeaders(t *testing.T) {
 	require.Equal(t, res.Header.Get("bar"), "baz")
 }
 
+func TestResponseSetCookie(t *testing.T) {
+	t.Parallel()
+
+	res := NewResponse()
+	cookie := &http.Cookie{Name: "session", Value: "abc"}
+	res.SetCookie(cookie)
+	require.Equal(t, res.Cookies, []*http.Cookie{cookie})
+}
+
 func TestResponseBody(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,117 @@
+package httpmock
+
+import "errors"
+
+// ErrSequenceExhausted stores the error returned when a mock responses sequence
+// has been consumed and SequenceFail is used.
+var ErrSequenceExhausted = errors.New("gock: mock responses sequence exhausted")
+
+// SequenceMode defines the behaviour of a mock once its responses sequence is exhausted.
+type SequenceMode int
+
+const (
+	// SequenceRepeatLast keeps replying with the last response of the sequence.
+	SequenceRepeatLast SequenceMode = iota
+
+	// SequenceFail replies with ErrSequenceExhausted.
+	SequenceFail
+
+	// SequenceCycle starts again from the first response of the sequence.
+	SequenceCycle
+)
+
+// ReplySequence defines an ordered list of responses, replying one per matched request.
+// The mock remains active for, at least, as many requests as responses are given.
+// The first response becomes the mock Response, while the Reply DSL keeps defining the last one.
+func (r *Request) ReplySequence(responses ...*Response) *Request {
+	if len(responses) == 0 {
+		return r
+	}
+
+	r.Responses = append([]*Response{}, responses...)
+	for _, res := range r.Responses {
+		res.Mock = r.Mock
+	}
+	r.setResponse(r.Responses[0])
+	r.builder = r.Responses[len(r.Responses)-1]
+
+	if r.Counter < len(r.Responses) {
+		r.Counter = len(r.Responses)
+	}
+	return r
+}
+
+// WhenExhausted defines the behaviour once the responses sequence has been consumed.
+// Use Times or Persist to keep the mock active after the sequence.
+func (r *Request) WhenExhausted(mode SequenceMode) *Request {
+	r.SequenceMode = mode
+	return r
+}
+
+// Then appends a new response to the mock responses sequence and returns
+// the Request DSL, so the next response can be defined via Reply.
+//
+//	New(url).Get("/").Reply(503).Then().Reply(200)
+func (r *Response) Then() *Request {
+	if r.Mock == nil {
+		// Attach the standalone response to a new unregistered mock
+		NewMock(NewRequest(), r)
+	}
+	return r.Mock.Request().then()
+}
+
+// then appends a new empty response to the responses sequence.
+func (r *Request) then() *Request {
+	if len(r.Responses) == 0 {
+		r.Responses = []*Response{r.Response}
+	}
+
+	res := NewResponse()
+	res.Mock = r.Mock
+	r.Responses = append(r.Responses, res)
+	r.builder = res
+
+	if r.Counter < len(r.Responses) {
+		r.Counter = len(r.Responses)
+	}
+	return r
+}
+
+// setResponse defines the given response as the mock Response, also for the parent Mocker.
+func (r *Request) setResponse(res *Response) {
+	if mocker, ok := r.Mock.(*Mocker); ok {
+		mocker.response = res
+	}
+	r.Response = res
+}
+
+// current returns the response currently defined via the Reply DSL:
+// the last one of the responses sequence, if any, or the mock Response.
+func (r *Request) current() *Response {
+	if r.builder != nil {
+		return r.builder
+	}
+	return r.Response
+}
+
+// responseAt returns the response used to reply the n-th matched request, starting by zero.
+func (r *Request) responseAt(n int) *Response {
+	if len(r.Responses) == 0 {
+		return r.Mock.Response()
+	}
+	if n < 0 {
+		n = 0
+	}
+	if n < len(r.Responses) {
+		return r.Responses[n]
+	}
+
+	switch r.SequenceMode {
+	case SequenceFail:
+		return &Response{Mock: r.Mock, Error: ErrSequenceExhausted}
+	case SequenceCycle:
+		return r.Responses[n%len(r.Responses)]
+	default:
+		return r.Responses[len(r.Responses)-1]
+	}
+}
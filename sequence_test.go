@@ -0,0 +1,129 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getStatuses(t *testing.T, url string, n int) []int {
+	t.Helper()
+
+	statuses := []int{}
+	for i := 0; i < n; i++ {
+		res, err := http.Get(url)
+		require.NoError(t, err)
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		statuses = append(statuses, res.StatusCode)
+	}
+	return statuses
+}
+
+func TestSequenceThen(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Get("/").
+		Reply(503).
+		Then().
+		Reply(503).
+		Then().
+		Reply(200).
+		BodyString("ok")
+
+	require.Equal(t, []int{503, 503, 200}, getStatuses(t, s.URL, 3))
+	require.True(t, IsDone(t))
+}
+
+func TestSequenceRepeatLast(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Get("/").
+		Persist().
+		ReplySequence(NewResponse().Status(500), NewResponse().Status(201))
+
+	require.Equal(t, []int{500, 201, 201, 201}, getStatuses(t, s.URL, 4))
+}
+
+func TestSequenceCycle(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Get("/").
+		Times(5).
+		WhenExhausted(SequenceCycle).
+		ReplySequence(NewResponse().Status(500), NewResponse().Status(201))
+
+	require.Equal(t, []int{500, 201, 500, 201, 500}, getStatuses(t, s.URL, 5))
+	require.True(t, IsDone(t))
+}
+
+func TestSequenceFail(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	New(s.URL).
+		Get("/").
+		Persist().
+		WhenExhausted(SequenceFail).
+		Reply(429).
+		Then().
+		Reply(200)
+
+	require.Equal(t, []int{429, 200, http.StatusNotImplemented}, getStatuses(t, s.URL, 3))
+}
+
+func TestSequenceResponseAt(t *testing.T) {
+	t.Parallel()
+
+	req := NewRequest()
+	res := NewResponse()
+	NewMock(req, res)
+	require.Equal(t, res, req.responseAt(3))
+
+	req.Reply(201).Then().Reply(202)
+	require.Equal(t, 2, req.Counter)
+	require.Equal(t, 201, req.responseAt(0).StatusCode)
+	require.Equal(t, 202, req.responseAt(1).StatusCode)
+	require.Equal(t, 202, req.responseAt(2).StatusCode)
+
+	req.WhenExhausted(SequenceFail)
+	require.Equal(t, ErrSequenceExhausted, req.responseAt(2).Error)
+}
+
+func TestSequenceMockResponse(t *testing.T) {
+	t.Parallel()
+
+	first, second := NewResponse().Status(500), NewResponse().Status(201)
+	req := NewRequest()
+	mock := NewMock(req, NewResponse())
+	req.ReplySequence(first, second).Reply(202)
+
+	// The mock and the request share the first response, while the DSL defines the last one
+	require.Same(t, first, mock.Response())
+	require.Same(t, first, req.Response)
+	require.Equal(t, 500, first.StatusCode)
+	require.Equal(t, 202, second.StatusCode)
+
+	req.Reply(201).Then().Reply(204)
+	require.Same(t, first, mock.Response())
+	require.Equal(t, 201, req.responseAt(1).StatusCode)
+	require.Equal(t, 204, req.responseAt(2).StatusCode)
+}
+
+func TestSequenceThenStandaloneResponse(t *testing.T) {
+	t.Parallel()
+
+	res := NewResponse().Status(500)
+	req := res.Then()
+	req.Reply(200)
+	require.Equal(t, 500, req.responseAt(0).StatusCode)
+	require.Equal(t, 200, req.responseAt(1).StatusCode)
+}
@@ -2,59 +2,248 @@ package httpmock
 
 import (
 	"fmt"
-	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"strings"
 	"testing"
-
-	"github.com/stretchr/testify/assert"
 )
 
-func Server(t *testing.T) *httptest.Server {
+// ServerOption configures the mock server created by Server and ServerTLS.
+type ServerOption func(*serverConfig)
+
+// serverConfig stores the mock server settings defined via ServerOption.
+type serverConfig struct {
+	// http2 enables HTTP/2 on TLS servers.
+	http2 bool
+
+	// upstream stores the real server URL used in recording mode.
+	upstream string
+
+	// fixtures stores the fixture file path used in recording mode.
+	fixtures string
+
+	// strict enables failing the test on pending mocks and unmatched requests.
+	strict bool
+
+	// observers stores the server specific observer functions.
+	observers []ObserverFunc
+
+	// responseObservers stores the server specific response observer functions.
+	responseObservers []ResponseObserverFunc
+
+	// openAPI stores the OpenAPI document file path used to validate the requests and mock responses.
+	openAPI string
+
+	// admin enables the admin API.
+	admin bool
+}
+
+// WithHTTP2 enables HTTP/2 support in the mock server.
+// It only takes effect on TLS servers created via ServerTLS.
+func WithHTTP2() ServerOption {
+	return func(c *serverConfig) {
+		c.http2 = true
+	}
+}
+
+// WithRecording enables the record and replay mode.
+// Fixtures stored in the given file are replayed as mocks, while requests which
+// don't match any mock are forwarded to the upstream URL and recorded.
+// The fixture file is written when the test finishes.
+func WithRecording(upstream, fixtures string) ServerOption {
+	return func(c *serverConfig) {
+		c.upstream = upstream
+		c.fixtures = fixtures
+	}
+}
+
+// WithStrict enables the strict mode, which fails the test when it finishes
+// if the server has pending mocks or has received requests which didn't match any mock.
+func WithStrict() ServerOption {
+	return func(c *serverConfig) {
+		c.strict = true
+	}
+}
+
+// WithAdmin enables the admin API of the server under AdminPrefix, see AdminPrefix.
+func WithAdmin() ServerOption {
+	return func(c *serverConfig) {
+		c.admin = true
+	}
+}
+
+// WithObserver adds an observer function invoked with every request received by the server
+// and its matched mock. The global observer defined via Observe is not used by the server then.
+func WithObserver(fn ObserverFunc) ServerOption {
+	return func(c *serverConfig) {
+		c.observers = append(c.observers, fn)
+	}
+}
+
+// WithResponseObserver adds an observer function invoked with every request received by the server,
+// its matched mock and the produced response or error.
+func WithResponseObserver(fn ResponseObserverFunc) ServerOption {
+	return func(c *serverConfig) {
+		c.responseObservers = append(c.responseObservers, fn)
+	}
+}
+
+// WithOpenAPIValidation enables validating every request received by the server, and the mock
+// response replied to it, against the OpenAPI 3 document stored in the given file.
+// The test fails when a request or response violates the document.
+func WithOpenAPIValidation(path string) ServerOption {
+	return func(c *serverConfig) {
+		c.openAPI = path
+	}
+}
+
+// Server starts a new plain HTTP mock server bound to the given test.
+// Mocks created via New(server.URL) are matched against the requests it receives.
+func Server(t *testing.T, opts ...ServerOption) *httptest.Server {
 	t.Helper()
+	return newServer(t, false, opts...)
+}
+
+// ServerTLS starts a new HTTPS mock server bound to the given test.
+// Use server.Client() to get an *http.Client which trusts the server certificate.
+func ServerTLS(t *testing.T, opts ...ServerOption) *httptest.Server {
+	t.Helper()
+	return newServer(t, true, opts...)
+}
+
+func newServer(t *testing.T, tls bool, opts ...ServerOption) *httptest.Server {
+	t.Helper()
+
+	config := &serverConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	mocks := register(t)
+	handler := newHandler(mocks, t.Errorf)
+	transport := handler.transport
+	for _, fn := range config.observers {
+		transport.Observe(fn)
+	}
+	for _, fn := range config.responseObservers {
+		transport.ObserveResponse(fn)
+	}
+	if config.openAPI != "" {
+		doc, err := ReadOpenAPI(config.openAPI)
+		if err != nil {
+			t.Fatalf("httpmock: cannot load OpenAPI document from %s: %v", config.openAPI, err)
+		}
+		transport.Validate(doc, t.Errorf)
+	}
+
+	if config.admin {
+		handler.EnableAdmin()
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	if tls {
+		server.EnableHTTP2 = config.http2
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+
+	if err := handler.setURL(server.URL); err != nil {
+		server.Close()
+		t.Fatalf("httpmock: invalid server url %q: %v", server.URL, err)
+	}
+	registerURL(mocks, server.URL)
 
-	var server *httptest.Server
-	var transport *Transport
-	server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
-		_, r.URL.Host, _ = strings.Cut(server.URL, "://")
-		rsp, err := transport.RoundTrip(r)
-		// if !assert.NoError(t, err) {
-		// 	return
-		// }
+	// Cleanups run in reverse order: the server is closed, waiting for the in-flight requests,
+	// before the mocks are flushed
+	t.Cleanup(mocks.Off)
+	t.Cleanup(server.Close)
+
+	if config.fixtures != "" {
+		recorder, err := NewRecorder(config.upstream, config.fixtures)
 		if err != nil {
-			_ = err
-			rw.WriteHeader(http.StatusNotImplemented)
-			rw.Write([]byte(err.Error()))
-			return
+			t.Fatalf("httpmock: cannot create recorder: %v", err)
 		}
-		body, err := io.ReadAll(rsp.Body)
-		defer rsp.Body.Close()
-		if !assert.NoError(t, err) {
-			return
+		for _, fixture := range recorder.Fixtures() {
+			fixture.Mock(server.URL)
 		}
-		h := rw.Header()
-		h2 := rsp.Header
-		for k, vv := range h2 {
-			for _, v := range vv {
-				h.Add(k, v)
+		transport.recorder = recorder
+
+		t.Cleanup(func() {
+			if err := recorder.Save(); err != nil {
+				t.Errorf("httpmock: cannot save fixtures: %v", err)
 			}
+		})
+	}
+
+	if config.strict {
+		t.Cleanup(func() {
+			verifyServer(t, server, mocks)
+		})
+	}
+
+	return server
+}
+
+// verifyServer fails the test if there are pending mocks for the given server
+// or the server has received unmatched requests.
+func verifyServer(t testing.TB, server *httptest.Server, mocks *_mocks) {
+	t.Helper()
+
+	buf := &strings.Builder{}
+
+	pending := []Mock{}
+	for _, mock := range mocks.Pending() {
+		if servesMock(server.URL, mock) {
+			pending = append(pending, mock)
 		}
+	}
+	if len(pending) > 0 {
+		fmt.Fprintf(buf, "\n%d pending mock(s):\n", len(pending))
+		for _, mock := range pending {
+			fmt.Fprintf(buf, "  %s\n", describeMock(mock))
+		}
+	}
 
-		rw.WriteHeader(rsp.StatusCode)
-		_, err = rw.Write(body)
-		assert.NoError(t, err)
-	}))
+	unmatched := []*http.Request{}
+	for _, req := range mocks.UnmatchedRequests() {
+		if servesRequest(server.URL, req) {
+			unmatched = append(unmatched, req)
+		}
+	}
+	if len(unmatched) > 0 {
+		fmt.Fprintf(buf, "\n%d unmatched request(s):\n", len(unmatched))
+		for _, req := range unmatched {
+			fmt.Fprintf(buf, "  %s %s\n", req.Method, req.URL)
+		}
+	}
 
-	mocks := register(t)
-	registerURL(mocks, server.URL)
-	transport = NewTransport(mocks)
+	if buf.Len() > 0 {
+		t.Errorf("httpmock: server %s has unmet expectations:\n%s", server.URL, buf)
+	}
+}
 
-	t.Cleanup(server.Close)
-	t.Cleanup(mocks.Off)
+// servesMock returns true if the given mock is registered for the server with the given URL,
+// or for any host.
+func servesMock(serverURL string, mock Mock) bool {
+	u := mock.Request().URLStruct
+	return u.Host == "" || strings.HasSuffix(serverURL, "://"+u.Host)
+}
 
-	return server
+// servesRequest returns true if the given request has been received by the server with the given URL.
+func servesRequest(serverURL string, req *http.Request) bool {
+	return req.URL != nil && strings.HasSuffix(serverURL, "://"+req.URL.Host)
+}
+
+// describeMock returns a short human readable representation of the given mock.
+func describeMock(mock Mock) string {
+	ereq := mock.Request()
+	desc := mockTarget(mock)
+	if ereq.Persisted {
+		return desc + " (persisted)"
+	}
+	return fmt.Sprintf("%s (%d pending)", desc, ereq.Counter)
 }
 
 // Observe(DumpNoMatchersRequest)
@@ -2,9 +2,11 @@ package httpmock
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -29,6 +31,59 @@ func Test_Server(t *testing.T) {
 	require.JSONEq(`{"i": 2}`, string(body))
 }
 
+func Test_ServerTLS(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	s := ServerTLS(t)
+	require.True(strings.HasPrefix(s.URL, "https://"))
+
+	New(s.URL).
+		Get("/secure").
+		Reply(200).
+		BodyString("ok")
+
+	resp, body := SendRequestAndGetResponse(t, http.MethodGet, s, "/secure", nil, map[string]string{})
+
+	require.Equal(200, resp.StatusCode)
+	require.Equal("HTTP/1.1", resp.Proto)
+	require.Equal("ok", string(body))
+	require.True(IsDone(t))
+}
+
+func Test_ServerTLSHTTP2(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	s := ServerTLS(t, WithHTTP2())
+
+	New(s.URL).
+		Get("/").
+		Reply(204)
+
+	resp, _ := SendRequestAndGetResponse(t, http.MethodGet, s, "/", nil, map[string]string{})
+
+	require.Equal(204, resp.StatusCode)
+	require.Equal(2, resp.ProtoMajor)
+}
+
+func Test_ServerTLSSchemeMismatch(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	s := ServerTLS(t)
+
+	New(s.URL).
+		Get("/").
+		Reply(200)
+	mocks := load(s.URL)
+	mocks.mocks[0].Request().URLStruct.Scheme = "http"
+
+	resp, _ := SendRequestAndGetResponse(t, http.MethodGet, s, "/", nil, map[string]string{})
+
+	require.Equal(http.StatusNotImplemented, resp.StatusCode)
+}
+
 func SendRequestAndGetResponse(t *testing.T, method string, server *httptest.Server, path string, body io.Reader, header map[string]string) (*http.Response, []byte) {
 	t.Helper()
 
@@ -58,3 +113,77 @@ func SendRequestAndGetResponse(t *testing.T, method string, server *httptest.Ser
 
 	return resp, b
 }
+
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func Test_ServerStrict(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	s := Server(t, WithStrict())
+
+	New(s.URL).
+		Get("/").
+		Reply(200)
+
+	resp, _ := SendRequestAndGetResponse(t, http.MethodGet, s, "/", nil, map[string]string{})
+	require.Equal(200, resp.StatusCode)
+
+	// A pending mock and an unmatched request fail the verification run on cleanup
+	failing := Server(t)
+	New(failing.URL).Get("/foo").Reply(200)
+	resp, _ = SendRequestAndGetResponse(t, http.MethodGet, failing, "/bar", nil, map[string]string{})
+	require.Equal(http.StatusNotImplemented, resp.StatusCode)
+
+	rt := &recordingT{TB: t}
+	verifyServer(rt, failing, load(failing.URL))
+	require.Len(rt.errors, 1)
+	require.Contains(rt.errors[0], "httpmock: server "+failing.URL+" has unmet expectations:")
+	require.Contains(rt.errors[0], "1 pending mock(s):\n  GET "+failing.URL+"/foo (1 pending)")
+	require.Contains(rt.errors[0], "1 unmatched request(s):\n  GET "+failing.URL+"/bar")
+}
+
+func Test_ServerStrictVerify(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	s := Server(t)
+	other := Server(t)
+	mocks := load(s.URL)
+
+	New(s.URL).Get("/foo").Reply(200)
+	New(s.URL).Post("/bar").Times(2).Reply(201)
+
+	// Unmatched requests are reported once, and only for the server which received them
+	res, err := http.Get(s.URL + "/baz")
+	require.NoError(err)
+	require.Equal(http.StatusNotImplemented, res.StatusCode)
+	_, err = http.Get(other.URL + "/qux")
+	require.NoError(err)
+	require.Len(mocks.UnmatchedRequests(), 2)
+
+	rt := &recordingT{TB: t}
+	verifyServer(rt, s, mocks)
+	require.Len(rt.errors, 1)
+	require.Contains(rt.errors[0], "2 pending mock(s):")
+	require.Contains(rt.errors[0], "GET "+s.URL+"/foo (1 pending)")
+	require.Contains(rt.errors[0], "POST "+s.URL+"/bar (2 pending)")
+	require.Contains(rt.errors[0], "1 unmatched request(s):")
+	require.Contains(rt.errors[0], "GET "+s.URL+"/baz")
+	require.NotContains(rt.errors[0], "/qux")
+
+	mocks.Flush()
+	mocks.CleanUnmatchedRequests()
+	rt = &recordingT{TB: t}
+	verifyServer(rt, s, mocks)
+	require.Len(rt.errors, 0)
+}
@@ -0,0 +1,61 @@
+package httpmock
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSEEvent represents a Server-Sent Event of a text/event-stream response.
+type SSEEvent struct {
+	// ID stores the optional event id.
+	ID string
+
+	// Event stores the optional event type.
+	Event string
+
+	// Data stores the event data, multiple lines are sent as multiple data fields.
+	Data string
+
+	// Retry stores the optional reconnection time sent to the client.
+	Retry time.Duration
+
+	// Delay stores the time to wait before sending the event.
+	Delay time.Duration
+}
+
+// SSE appends the given events to the response body, streamed as text/event-stream.
+// Every event is sent after its delay and flushed to the client, both through
+// Transport, as a slow response body, and through the Server handler.
+//
+//	New(url).Get("/events").Reply(200).SSE(
+//		SSEEvent{ID: "1", Data: "hello"},
+//		SSEEvent{ID: "2", Data: "world", Delay: 100 * time.Millisecond},
+//	)
+func (r *Response) SSE(events ...SSEEvent) *Response {
+	r.Header.Set("Content-Type", "text/event-stream")
+	r.Header.Set("Cache-Control", "no-cache")
+	for _, event := range events {
+		r.ChunkString(event.String(), event.Delay)
+	}
+	return r
+}
+
+// String returns the event encoded in the text/event-stream format.
+func (e SSEEvent) String() string {
+	buf := &strings.Builder{}
+	if e.ID != "" {
+		buf.WriteString("id: " + e.ID + "\n")
+	}
+	if e.Event != "" {
+		buf.WriteString("event: " + e.Event + "\n")
+	}
+	if e.Retry > 0 {
+		buf.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+	for _, line := range strings.Split(e.Data, "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+	buf.WriteString("\n")
+	return buf.String()
+}
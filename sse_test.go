@@ -0,0 +1,126 @@
+package httpmock
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEEventString(t *testing.T) {
+	t.Parallel()
+
+	event := SSEEvent{ID: "1", Event: "update", Data: "foo\nbar", Retry: 2 * time.Second}
+	require.Equal(t, "id: 1\nevent: update\nretry: 2000\ndata: foo\ndata: bar\n\n", event.String())
+	require.Equal(t, "data: foo\n\n", SSEEvent{Data: "foo"}.String())
+}
+
+func TestSSEServer(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/events").Reply(200).SSE(
+		SSEEvent{ID: "1", Data: "foo"},
+		SSEEvent{ID: "2", Data: "bar", Delay: 200 * time.Millisecond},
+	)
+
+	start := time.Now()
+	res, err := http.Get(s.URL + "/events")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	require.Equal(t, "no-cache", res.Header.Get("Cache-Control"))
+	require.Equal(t, int64(-1), res.ContentLength)
+
+	// The first event is flushed before the delayed second one is sent
+	reader := bufio.NewReader(res.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "id: 1\n", line)
+	require.Less(t, int64(time.Since(start)), int64(200*time.Millisecond))
+
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "data: foo\n\nid: 2\ndata: bar\n\n", string(rest))
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(200*time.Millisecond))
+}
+
+func TestSSETransport(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/events").Reply(200).SSE(
+		SSEEvent{Event: "ping", Data: "1"},
+		SSEEvent{Event: "ping", Data: "2", Delay: 50 * time.Millisecond},
+	)
+
+	start := time.Now()
+	res, err := client.Get(s.URL + "/events")
+	require.NoError(t, err)
+	require.Less(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	require.Equal(t, []string{"chunked"}, res.TransferEncoding)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "event: ping\ndata: 1\n\nevent: ping\ndata: 2\n\n", string(body))
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+}
+
+func TestSSECanceledContext(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/events").Reply(200).SSE(
+		SSEEvent{Data: "foo"},
+		SSEEvent{Data: "bar", Delay: time.Hour},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/events", nil)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+	n, err := res.Body.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "data: foo\n\n", string(buf[:n]))
+
+	cancel()
+	_, err = res.Body.Read(buf)
+	require.Equal(t, context.Canceled, err)
+}
+
+func TestSSECompression(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/events").Reply(200).Compression("gzip").SSE(
+		SSEEvent{ID: "1", Data: "foo"},
+		SSEEvent{ID: "2", Data: "bar", Delay: 200 * time.Millisecond},
+	)
+
+	// Ask for gzip explicitly, so the client doesn't decompress the body transparently
+	req, _ := http.NewRequest("GET", s.URL+"/events", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	start := time.Now()
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "gzip", res.Header.Get("Content-Encoding"))
+
+	// Every event is compressed and flushed on its own
+	body, err := gzip.NewReader(res.Body)
+	require.NoError(t, err)
+	reader := bufio.NewReader(body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "id: 1\n", line)
+	require.Less(t, int64(time.Since(start)), int64(200*time.Millisecond))
+
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "data: foo\n\nid: 2\ndata: bar\n\n", string(rest))
+}
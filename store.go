@@ -1,6 +1,7 @@
 package httpmock
 
 import (
+	"net/http"
 	"sync"
 )
 
@@ -10,6 +11,12 @@ var storeMutex = sync.RWMutex{}
 // mocks is internally used to store registered mocks.
 type _mocks struct {
 	mocks []Mock
+
+	// history stores the requests matched by the registered mocks.
+	history []*Call
+
+	// unmatched stores the requests which haven't matched any registered mock.
+	unmatched []*http.Request
 }
 
 // Register registers a new mock in the current mocks stack.
@@ -111,3 +111,22 @@ func TestStoreFlush(t *testing.T) {
 	require.Equal(t, mocks.Exists(mock1), false)
 	require.Equal(t, mocks.Exists(mock2), false)
 }
+
+func TestStoreOff(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	mocks := load(s.URL)
+	New(s.URL)
+	mocks.trackUnmatchedRequest(nil)
+
+	// Off flushes the registry itself, not a copy of it, keeping the unmatched requests
+	mocks.Off()
+	require.Len(t, mocks.mocks, 0)
+	require.Len(t, mocks.UnmatchedRequests(), 1)
+
+	New(s.URL)
+	mocks.OffAll()
+	require.Len(t, mocks.mocks, 0)
+	require.Len(t, mocks.UnmatchedRequests(), 0)
+}
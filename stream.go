@@ -0,0 +1,82 @@
+package httpmock
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BodyChunk represents a part of a streamed response body.
+type BodyChunk struct {
+	// Delay stores the time to wait before sending the chunk.
+	Delay time.Duration
+
+	// Data stores the chunk data.
+	Data []byte
+}
+
+// Chunk appends the given data to the response body, sent after the given delay.
+// Chunked bodies are replied with Transfer-Encoding: chunked, flushed chunk by chunk
+// by the mock server and read as a slow body through the mock transport, e.g.
+//
+//	New(url).Get("/stream").Reply(200).
+//		ChunkString("foo", 0).
+//		ChunkString("bar", 100*time.Millisecond)
+func (r *Response) Chunk(data []byte, delay time.Duration) *Response {
+	r.BodyChunks = append(r.BodyChunks, BodyChunk{Delay: delay, Data: data})
+	return r
+}
+
+// ChunkString appends the given string to the response body, sent after the given delay.
+func (r *Response) ChunkString(data string, delay time.Duration) *Response {
+	return r.Chunk([]byte(data), delay)
+}
+
+// Chunks appends the given chunks to the response body.
+func (r *Response) Chunks(chunks ...BodyChunk) *Response {
+	r.BodyChunks = append(r.BodyChunks, chunks...)
+	return r
+}
+
+// chunkReader implements io.ReadCloser streaming the given chunks, waiting for their delay
+// before reading them. Every read returns data from a single chunk, so the chunks can be
+// flushed one by one.
+type chunkReader struct {
+	ctx     context.Context
+	chunks  []BodyChunk
+	current []byte
+}
+
+// newChunkReader creates a new chunkReader which stops waiting once the given context is done.
+func newChunkReader(ctx context.Context, chunks []BodyChunk) *chunkReader {
+	return &chunkReader{ctx: ctx, chunks: chunks}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.current) == 0 {
+		if len(r.chunks) == 0 {
+			return 0, io.EOF
+		}
+		chunk := r.chunks[0]
+		r.chunks = r.chunks[1:]
+
+		if chunk.Delay > 0 {
+			timer := time.NewTimer(chunk.Delay)
+			select {
+			case <-timer.C:
+			case <-r.ctx.Done():
+				timer.Stop()
+				return 0, r.ctx.Err()
+			}
+		}
+		r.current = chunk.Data
+	}
+
+	n := copy(p, r.current)
+	r.current = r.current[n:]
+	return n, nil
+}
+
+func (r *chunkReader) Close() error {
+	return nil
+}
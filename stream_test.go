@@ -0,0 +1,102 @@
+package httpmock
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkReader(t *testing.T) {
+	t.Parallel()
+
+	reader := newChunkReader(context.Background(), []BodyChunk{
+		{Data: []byte("foo")},
+		{Data: []byte{}},
+		{Data: []byte("barbaz"), Delay: 10 * time.Millisecond},
+	})
+
+	// Every read returns data from a single chunk
+	buf := make([]byte, 4)
+	n, err := reader.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(buf[:n]))
+
+	start := time.Now()
+	n, err = reader.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "barb", string(buf[:n]))
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(10*time.Millisecond))
+
+	n, err = reader.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "az", string(buf[:n]))
+
+	_, err = reader.Read(buf)
+	require.Equal(t, io.EOF, err)
+	require.NoError(t, reader.Close())
+}
+
+func TestChunkedServer(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/stream").Reply(200).
+		ChunkString("foo", 0).
+		Chunks(BodyChunk{Data: []byte("bar"), Delay: 200 * time.Millisecond})
+
+	start := time.Now()
+	res, err := http.Get(s.URL + "/stream")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, []string{"chunked"}, res.TransferEncoding)
+	require.Equal(t, int64(-1), res.ContentLength)
+
+	// The first chunk is flushed before the delayed second one is sent
+	buf := make([]byte, 3)
+	_, err = io.ReadFull(res.Body, buf)
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(buf))
+	require.Less(t, int64(time.Since(start)), int64(200*time.Millisecond))
+
+	rest, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(rest))
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(200*time.Millisecond))
+}
+
+func TestChunkedTransport(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/stream").Reply(200).
+		Chunk([]byte("foo"), 0).
+		Chunk([]byte("bar"), 20*time.Millisecond)
+
+	res, err := client.Get(s.URL + "/stream")
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), res.ContentLength)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "foobar", string(body))
+}
+
+func TestChunkedClientTimeout(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/stream").Reply(200).
+		ChunkString("foo", 0).
+		ChunkString("bar", time.Hour)
+
+	// The headers and first chunk are received within the timeout, the second chunk is not
+	client := &http.Client{Timeout: 100 * time.Millisecond}
+	res, err := client.Get(s.URL + "/stream")
+	require.NoError(t, err)
+	_, err = io.ReadAll(res.Body)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout())
+}
@@ -0,0 +1,57 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Trailer sets a trailer field sent after the response body.
+// Responses with trailers are replied with Transfer-Encoding: chunked. Through Transport
+// the trailer keys are announced in http.Response.Trailer and their values are only
+// available once the body is read to its end, as with real connections.
+func (r *Response) Trailer(key, value string) *Response {
+	if r.Trailers == nil {
+		r.Trailers = make(http.Header)
+	}
+	r.Trailers.Set(key, value)
+	return r
+}
+
+// trailerReader implements io.ReadCloser reading the given body and defining
+// the trailer values once it's read to its end.
+type trailerReader struct {
+	body     io.ReadCloser
+	trailer  http.Header
+	trailers http.Header
+}
+
+// newTrailerReader creates a new trailerReader filling the given trailer with the given trailers.
+func newTrailerReader(body io.ReadCloser, trailer, trailers http.Header) *trailerReader {
+	return &trailerReader{body: body, trailer: trailer, trailers: trailers}
+}
+
+func (r *trailerReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if err == io.EOF {
+		for key, values := range r.trailers {
+			r.trailer[key] = append([]string(nil), values...)
+		}
+	}
+	return n, err
+}
+
+func (r *trailerReader) Close() error {
+	return r.body.Close()
+}
+
+// declareTrailers announces the given trailer keys via the Trailer header field.
+func declareTrailers(header, trailer http.Header) {
+	keys := make([]string, 0, len(trailer))
+	for key := range trailer {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	header.Set("Trailer", strings.Join(keys, ", "))
+}
@@ -0,0 +1,62 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrailerTransport(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	client := &http.Client{Transport: NewTransport(load(s.URL))}
+	New(s.URL).Get("/download").Reply(200).BodyString("foo bar").Trailer("x-checksum", "abc")
+
+	res, err := client.Get(s.URL + "/download")
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), res.ContentLength)
+	require.Equal(t, http.Header{"X-Checksum": nil}, res.Trailer)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "foo bar", string(body))
+	require.Equal(t, "abc", res.Trailer.Get("X-Checksum"))
+}
+
+func TestTrailerServer(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/download").Reply(200).
+		BodyString("foo bar").
+		Trailer("X-Checksum", "abc").
+		Trailer("X-Size", "7")
+
+	res, err := http.Get(s.URL + "/download")
+	require.NoError(t, err)
+	require.Equal(t, []string{"chunked"}, res.TransferEncoding)
+	require.Equal(t, http.Header{"X-Checksum": nil, "X-Size": nil}, res.Trailer)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "foo bar", string(body))
+	require.Equal(t, "abc", res.Trailer.Get("X-Checksum"))
+	require.Equal(t, "7", res.Trailer.Get("X-Size"))
+}
+
+func TestTrailerChunkedServer(t *testing.T) {
+	t.Parallel()
+	s := Server(t)
+	New(s.URL).Get("/stream").Reply(200).
+		ChunkString("foo", 0).
+		ChunkString("bar", 0).
+		Trailer("X-Checksum", "abc")
+
+	res, err := http.Get(s.URL + "/stream")
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "foobar", string(body))
+	require.Equal(t, "abc", res.Trailer.Get("X-Checksum"))
+}
@@ -30,6 +30,21 @@ type Transport struct {
 	mutex sync.Mutex
 
 	mocks *_mocks
+
+	// recorder stores the optional recorder used to forward unmatched requests.
+	recorder *Recorder
+
+	// observers stores the functions invoked with every intercepted request and its matched mock.
+	observers []ObserverFunc
+
+	// responseObservers stores the functions invoked with every produced response.
+	responseObservers []ResponseObserverFunc
+
+	// validator stores the optional OpenAPI document the requests and mock responses are validated against.
+	validator *OpenAPI
+
+	// validationErrorf is used to report the validation errors.
+	validationErrorf func(format string, args ...interface{})
 }
 
 // NewTransport creates a new *Transport with no responders.
@@ -46,6 +61,40 @@ func NewTransport(mocks *_mocks) *Transport {
 // implement the http.RoundTripper interface.  You will not interact with this directly, instead
 // the *http.Client you are using will call it for you.
 func (m *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mock, res, err := m.roundTrip(req)
+	m.observeResponse(req, mock, res, err)
+	return res, err
+}
+
+// Observe adds a new observer function invoked with every intercepted request and its matched mock,
+// before the response is built. The global observer defined via Observe is only used as a fallback
+// when the transport has no observers.
+func (m *Transport) Observe(fn ObserverFunc) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.observers = append(m.observers, fn)
+}
+
+// ObserveResponse adds a new observer function invoked with every intercepted request,
+// its matched mock and the produced response or error.
+func (m *Transport) ObserveResponse(fn ResponseObserverFunc) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.responseObservers = append(m.responseObservers, fn)
+}
+
+// Validate enables validating every intercepted request, and the mock response replied to it,
+// against the given OpenAPI document. Violations are reported via errorf, e.g. t.Errorf,
+// and don't prevent the request from being served. Responses computed by a reply handler aren't validated.
+func (m *Transport) Validate(doc *OpenAPI, errorf func(format string, args ...interface{})) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.validator = doc
+	m.validationErrorf = errorf
+}
+
+// roundTrip matches the given request and builds the mock response, returning the matched mock, if any.
+func (m *Transport) roundTrip(req *http.Request) (Mock, *http.Response, error) {
 	// Just act as a proxy if not intercepting
 	// if !Intercepting() {
 	// 	return m.Transport.RoundTrip(req)
@@ -59,25 +108,43 @@ func (m *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	var err error
 	var res *http.Response
 
+	// Validate the intercepted http.Request, if enabled
+	if m.validator != nil {
+		if err := m.validator.ValidateRequest(req); err != nil {
+			m.validationErrorf("%v", err)
+		}
+	}
+
 	// Match mock for the incoming http.Request
-	mock, err := mocks.MatchMock(req)
+	mock, results, err := mocks.matchMock(req)
 	if err != nil {
 		m.mutex.Unlock()
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Invoke the observer with the intercepted http.Request and matched mock
-	if config.Observer != nil {
-		config.Observer(req, mock)
-	}
+	// Invoke the observers with the intercepted http.Request and matched mock
+	m.observe(req, mock)
 
 	// Verify if should use real networking
 	// networking := shouldUseNetwork(req, mock)
 	// if !networking && mock == nil {
 	if mock == nil {
 		m.mutex.Unlock()
-		trackUnmatchedRequest(req)
-		return nil, ErrCannotMatch
+		if m.recorder != nil {
+			res, err = m.recorder.RoundTrip(req)
+			return nil, res, err
+		}
+		mocks.trackUnmatchedRequest(req)
+		return nil, nil, newMatchError(req, results)
+	}
+
+	// Pick the response for the current call, in case of responses sequence
+	calls := Calls(mock)
+	mres := mock.Request().responseAt(len(calls) - 1)
+	if m.validator != nil && mres.Error == nil && mres.ReplyHandler == nil {
+		if err := m.validator.ValidateResponse(req, mres); err != nil {
+			m.validationErrorf("%v", err)
+		}
 	}
 
 	// Ensure me unlock the mutex before building the response
@@ -92,7 +159,36 @@ func (m *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// 	}
 	// }
 
-	return Responder(req, mock.Response(), res)
+	res, err = Responder(req, mres, res)
+	if err == nil && len(calls) > 0 {
+		calls[len(calls)-1].recordResponse(res)
+	}
+	return mock, res, err
+}
+
+// observe invokes the transport observers, or the global one as fallback.
+// It must be called with the transport mutex locked.
+func (m *Transport) observe(req *http.Request, mock Mock) {
+	if len(m.observers) == 0 {
+		if config.Observer != nil {
+			config.Observer(req, mock)
+		}
+		return
+	}
+	for _, observer := range m.observers {
+		observer(req, mock)
+	}
+}
+
+// observeResponse invokes the transport response observers.
+func (m *Transport) observeResponse(req *http.Request, mock Mock, res *http.Response, err error) {
+	m.mutex.Lock()
+	observers := m.responseObservers
+	m.mutex.Unlock()
+
+	for _, observer := range observers {
+		observer(req, mock, res, err)
+	}
 }
 
 // CancelRequest is a no-op function.
@@ -1,6 +1,7 @@
 package httpmock
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
@@ -32,7 +33,58 @@ func TestTransportCannotMatch(t *testing.T) {
 	u, _ := url.Parse("http://127.0.0.1:1234")
 	req := &http.Request{URL: u}
 	_, err := NewTransport(mocks).RoundTrip(req)
-	require.Equal(t, err, ErrCannotMatch)
+	require.ErrorIs(t, err, ErrCannotMatch)
+}
+
+func TestTransportObservers(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	mocks := load(s.URL)
+	New(s.URL).Reply(204)
+
+	transport := NewTransport(mocks)
+	phases := []string{}
+	transport.Observe(func(req *http.Request, mock Mock) {
+		phases = append(phases, fmt.Sprintf("match %v", mock != nil))
+	})
+	transport.Observe(func(req *http.Request, mock Mock) {
+		phases = append(phases, "match2")
+	})
+	transport.ObserveResponse(func(req *http.Request, mock Mock, res *http.Response, err error) {
+		phases = append(phases, fmt.Sprintf("response %v %v", res != nil, err != nil))
+	})
+
+	u, _ := url.Parse(s.URL)
+	res, err := transport.RoundTrip(&http.Request{URL: u})
+	require.NoError(t, err)
+	require.Equal(t, 204, res.StatusCode)
+	require.Equal(t, []string{"match true", "match2", "response true false"}, phases)
+
+	_, err = transport.RoundTrip(&http.Request{URL: u, Header: make(http.Header)})
+	require.ErrorIs(t, err, ErrCannotMatch)
+	require.Equal(t, []string{"match true", "match2", "response true false", "match false", "match2", "response false true"}, phases)
+}
+
+func TestServerObservers(t *testing.T) {
+	t.Parallel()
+
+	var observed *http.Request
+	var status int
+	s := Server(t,
+		WithObserver(func(req *http.Request, mock Mock) {
+			observed = req
+		}),
+		WithResponseObserver(func(req *http.Request, mock Mock, res *http.Response, err error) {
+			status = res.StatusCode
+		}),
+	)
+	New(s.URL).Get("/foo").Reply(202)
+
+	_, err := http.Get(s.URL + "/foo")
+	require.NoError(t, err)
+	require.Equal(t, "/foo", observed.URL.Path)
+	require.Equal(t, 202, status)
 }
 
 //
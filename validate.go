@@ -0,0 +1,389 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidateRequest checks the given request against the OpenAPI document, returning an error
+// if its path or method is not documented, a required parameter is missing or invalid,
+// or its JSON body doesn't satisfy the documented schema.
+func (d *OpenAPI) ValidateRequest(req *http.Request) error {
+	op, params, err := d.findOperation(req.Method, req.URL.Path)
+	if err != nil {
+		return err
+	}
+
+	for _, param := range params {
+		if err := d.validateParameter(req, param); err != nil {
+			return fmt.Errorf("gock: %s %s: %v", req.Method, req.URL.Path, err)
+		}
+	}
+
+	if err := d.validateRequestBody(req, d.requestBody(op.RequestBody)); err != nil {
+		return fmt.Errorf("gock: %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	return nil
+}
+
+// ValidateResponse checks the given mock response replied to the given request against
+// the OpenAPI document, returning an error if its status code is not documented by the
+// operation or its JSON body doesn't satisfy the documented schema.
+// Responses of undocumented operations are not validated, see ValidateRequest.
+func (d *OpenAPI) ValidateResponse(req *http.Request, res *Response) error {
+	op, _, err := d.findOperation(req.Method, req.URL.Path)
+	if err != nil {
+		return nil
+	}
+
+	status := res.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	dres := op.Responses[strconv.Itoa(status)]
+	if dres == nil {
+		dres = op.Responses[strconv.Itoa(status/100)+"XX"]
+	}
+	if dres == nil {
+		dres = op.Responses[strconv.Itoa(status/100)+"xx"]
+	}
+	if dres == nil {
+		dres = op.Responses["default"]
+	}
+	if dres == nil {
+		return fmt.Errorf("gock: %s %s: response status %d is not documented", req.Method, req.URL.Path, status)
+	}
+
+	if err := d.validateContent(d.response(dres).Content, res.Header.Get("Content-Type"), res.BodyBuffer); err != nil {
+		return fmt.Errorf("gock: %s %s: invalid %d response: %v", req.Method, req.URL.Path, status, err)
+	}
+	return nil
+}
+
+// openAPIRoute represents a documented path and the compiled regular expression matching it.
+type openAPIRoute struct {
+	path    string
+	pattern *regexp.Regexp
+}
+
+// findOperation returns the documented operation of the given method and path,
+// and its parameters including the path item ones.
+func (d *OpenAPI) findOperation(method, path string) (*OpenAPIOperation, []*OpenAPIParameter, error) {
+	d.routesOnce.Do(d.compileRoutes)
+
+	for _, route := range d.routes {
+		if !route.pattern.MatchString(path) {
+			continue
+		}
+
+		item := d.Paths[route.path]
+		op := item.operation(strings.ToUpper(method))
+		if op == nil {
+			return nil, nil, fmt.Errorf("gock: %s %s: method is not documented", method, path)
+		}
+
+		params := []*OpenAPIParameter{}
+		for _, param := range append(append([]*OpenAPIParameter{}, item.Parameters...), op.Parameters...) {
+			if param = d.parameter(param); param != nil {
+				params = append(params, param)
+			}
+		}
+		return op, params, nil
+	}
+	return nil, nil, fmt.Errorf("gock: %s %s: path is not documented", method, path)
+}
+
+// compileRoutes compiles the patterns of the documented paths, in matching order.
+func (d *OpenAPI) compileRoutes() {
+	for _, path := range d.sortedPaths() {
+		pattern, err := regexp.Compile(d.pathPattern(path))
+		if err != nil {
+			continue
+		}
+		d.routes = append(d.routes, openAPIRoute{path: path, pattern: pattern})
+	}
+}
+
+// validateParameter checks the given request satisfies the given parameter.
+// Path parameters are always present since the path has been matched already.
+func (d *OpenAPI) validateParameter(req *http.Request, param *OpenAPIParameter) error {
+	var values []string
+	switch param.In {
+	case "query":
+		values = req.URL.Query()[param.Name]
+	case "header":
+		values = req.Header.Values(param.Name)
+	case "cookie":
+		if cookie, err := req.Cookie(param.Name); err == nil {
+			values = []string{cookie.Value}
+		}
+	default:
+		return nil
+	}
+
+	if len(values) == 0 {
+		if param.Required {
+			return fmt.Errorf("missing required %s parameter %q", param.In, param.Name)
+		}
+		return nil
+	}
+
+	schema := d.schema(param.Schema)
+	if schema == nil {
+		return nil
+	}
+	if schema.Type == "array" {
+		schema = d.schema(schema.Items)
+	}
+	for _, value := range values {
+		if err := d.validateSchema(paramValue(value, schema), schema, param.Name); err != nil {
+			return fmt.Errorf("invalid %s parameter: %v", param.In, err)
+		}
+	}
+	return nil
+}
+
+// validateRequestBody checks the given request body satisfies the given documented body.
+func (d *OpenAPI) validateRequestBody(req *http.Request, body *OpenAPIRequestBody) error {
+	var data []byte
+	if req.Body != nil {
+		var err error
+		if data, err = io.ReadAll(req.Body); err != nil {
+			return err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if body == nil {
+		return nil
+	}
+	if len(data) == 0 {
+		if body.Required {
+			return fmt.Errorf("missing required request body")
+		}
+		return nil
+	}
+	return d.validateContent(body.Content, req.Header.Get("Content-Type"), data)
+}
+
+// validateContent checks the given body of the given content type satisfies the documented content.
+func (d *OpenAPI) validateContent(content map[string]*OpenAPIMediaType, kind string, body []byte) error {
+	if len(content) == 0 || len(body) == 0 {
+		return nil
+	}
+
+	media := mediaFor(content, kind)
+	if media == nil {
+		return fmt.Errorf("content type %q is not documented", kind)
+	}
+	if media.Schema == nil || !strings.Contains(kind, "json") {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("invalid JSON body: %v", err)
+	}
+	return d.validateSchema(value, media.Schema, "body")
+}
+
+// validateSchema checks the given decoded JSON value satisfies the given schema.
+func (d *OpenAPI) validateSchema(value interface{}, schema *OpenAPISchema, path string) error {
+	schema = d.schema(schema)
+	if schema == nil {
+		return nil
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", path)
+	}
+
+	for _, sub := range schema.AllOf {
+		if err := d.validateSchema(value, sub, path); err != nil {
+			return err
+		}
+	}
+	if len(schema.AnyOf) > 0 && d.countValid(value, schema.AnyOf, path) == 0 {
+		return fmt.Errorf("%s: does not match any of the anyOf schemas", path)
+	}
+	if len(schema.OneOf) > 0 && d.countValid(value, schema.OneOf, path) != 1 {
+		return fmt.Errorf("%s: does not match exactly one of the oneOf schemas", path)
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(value, schema.Enum) {
+		return fmt.Errorf("%s: %v is not one of %v", path, value, schema.Enum)
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %s", path, jsonType(value))
+		}
+		return d.validateObject(obj, schema, path)
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %s", path, jsonType(value))
+		}
+		for i, item := range arr {
+			if err := d.validateSchema(item, schema.Items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string, got %s", path, jsonType(value))
+		}
+		if schema.MinLength != nil && len(s) < *schema.MinLength {
+			return fmt.Errorf("%s: length must be at least %d", path, *schema.MinLength)
+		}
+		if schema.MaxLength != nil && len(s) > *schema.MaxLength {
+			return fmt.Errorf("%s: length must be at most %d", path, *schema.MaxLength)
+		}
+		if schema.Pattern != "" {
+			if match, err := regexp.MatchString(schema.Pattern, s); err == nil && !match {
+				return fmt.Errorf("%s: %q does not match pattern %q", path, s, schema.Pattern)
+			}
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok || (schema.Type == "integer" && n != math.Trunc(n)) {
+			return fmt.Errorf("%s: expected %s, got %s", path, schema.Type, jsonType(value))
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			return fmt.Errorf("%s: %v must be at least %v", path, n, *schema.Minimum)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			return fmt.Errorf("%s: %v must be at most %v", path, n, *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %s", path, jsonType(value))
+		}
+	}
+	return nil
+}
+
+// validateObject checks the given JSON object satisfies the given object schema.
+func (d *OpenAPI) validateObject(obj map[string]interface{}, schema *OpenAPISchema, path string) error {
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", path, name)
+		}
+	}
+	// Properties are checked in a stable order, so the same error is reported for the same value
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := obj[name]
+		prop, ok := schema.Properties[name]
+		if !ok {
+			if allowed, ok := schema.AdditionalProperties.(bool); ok && !allowed {
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			}
+			continue
+		}
+		if err := d.validateSchema(value, prop, path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countValid returns the number of the given schemas satisfied by the given value.
+func (d *OpenAPI) countValid(value interface{}, schemas []*OpenAPISchema, path string) int {
+	count := 0
+	for _, schema := range schemas {
+		if d.validateSchema(value, schema, path) == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// mediaFor returns the documented media type of the given content type, supporting wildcards.
+func mediaFor(content map[string]*OpenAPIMediaType, kind string) *OpenAPIMediaType {
+	kind, _, _ = mime.ParseMediaType(kind)
+	if kind == "" {
+		_, media := contentMedia(content)
+		return media
+	}
+	if media, ok := content[kind]; ok {
+		return media
+	}
+	if i := strings.Index(kind, "/"); i > 0 {
+		if media, ok := content[kind[:i]+"/*"]; ok {
+			return media
+		}
+	}
+	return content["*/*"]
+}
+
+// paramValue converts the given parameter string value to the JSON type of the given schema.
+func paramValue(value string, schema *OpenAPISchema) interface{} {
+	switch schema.Type {
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// inEnum returns true if the given JSON value is one of the given enum values.
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		// Enum values are decoded from YAML, so numbers may be ints
+		if data, err := json.Marshal(e); err == nil {
+			json.Unmarshal(data, &e)
+		}
+		if reflect.DeepEqual(value, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON type name of the given decoded value.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,86 @@
+package httpmock
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportValidate(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t)
+	mocks := load(s.URL)
+	transport := NewTransport(mocks)
+	client := &http.Client{Transport: transport}
+
+	doc, err := ReadOpenAPI("testdata/openapi/users.yaml")
+	require.NoError(t, err)
+	var errors []string
+	transport.Validate(doc, func(format string, args ...interface{}) {
+		errors = append(errors, fmt.Sprintf(format, args...))
+	})
+
+	New(s.URL).Get("^/v1/users$").Persist().Reply(200).JSON([]map[string]interface{}{{"id": 1, "name": "foo"}})
+	New(s.URL).Post("^/v1/users$").Persist().Reply(201).JSON(map[string]interface{}{"id": 2, "name": "bar"})
+	New(s.URL).Get("/v1/users/1").Reply(500)
+	New(s.URL).Get("/v1/users/2").Reply(200).JSON(map[string]interface{}{"id": "2"})
+	New(s.URL).Get("/v1/unknown").Reply(200)
+
+	do := func(method, path, body string, header map[string]string) {
+		req, _ := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		_, err := client.Do(req)
+		require.NoError(t, err)
+	}
+
+	do("GET", "/v1/users?page=2", "", map[string]string{"X-Api-Key": "secret"})
+	do("POST", "/v1/users", `{"name":"bar","role":"admin"}`, map[string]string{"Content-Type": "application/json"})
+	require.Empty(t, errors)
+
+	do("GET", "/v1/users", "", nil)
+	do("GET", "/v1/users?page=0", "", map[string]string{"X-Api-Key": "secret"})
+	do("POST", "/v1/users", `{"name":`, map[string]string{"Content-Type": "application/json"})
+	do("POST", "/v1/users", `{"name":"","role":"owner"}`, map[string]string{"Content-Type": "application/json"})
+	do("POST", "/v1/users", `{"name":"foo","age":3}`, map[string]string{"Content-Type": "application/json"})
+	do("POST", "/v1/users", ``, map[string]string{"Content-Type": "application/json"})
+	do("POST", "/v1/users", `name=foo`, map[string]string{"Content-Type": "application/x-www-form-urlencoded"})
+	do("GET", "/v1/users/1", "", nil)
+	do("GET", "/v1/users/2", "", nil)
+	do("GET", "/v1/unknown", "", nil)
+
+	// Unmatched requests are validated as well
+	req, _ := http.NewRequest("PUT", s.URL+"/v1/users/1", nil)
+	_, err = client.Do(req)
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		`gock: GET /v1/users: missing required header parameter "X-Api-Key"`,
+		`gock: GET /v1/users: invalid query parameter: page: 0 must be at least 1`,
+		`gock: POST /v1/users: invalid JSON body: unexpected end of JSON input`,
+		`gock: POST /v1/users: body.name: length must be at least 1`,
+		`gock: POST /v1/users: body: unexpected property "age"`,
+		`gock: POST /v1/users: missing required request body`,
+		`gock: POST /v1/users: content type "application/x-www-form-urlencoded" is not documented`,
+		`gock: GET /v1/users/1: response status 500 is not documented`,
+		`gock: GET /v1/users/2: invalid 200 response: body: missing required property "name"`,
+		`gock: GET /v1/unknown: path is not documented`,
+		`gock: PUT /v1/users/1: method is not documented`,
+	}, errors)
+}
+
+func TestServerOpenAPIValidation(t *testing.T) {
+	t.Parallel()
+
+	s := Server(t, WithOpenAPIValidation("testdata/openapi/users.yaml"))
+	New(s.URL).Get("/v1/users/me").Reply(200).JSON(map[string]interface{}{"id": 0, "name": "me"})
+
+	res, err := http.Get(s.URL + "/v1/users/me")
+	require.NoError(t, err)
+	require.Equal(t, 200, res.StatusCode)
+}